@@ -139,6 +139,24 @@ var _ = Describe("generate dry-run CloudFormation templates", func() {
 			err := deployAppOpts.Execute()
 			Expect(err).Should(MatchError(HavePrefix("Application configuration refers to component nginx-replicated, but no file provided the component schematic")))
 		})
+
+		It("dropped attributes should return an error in strict mode", func() {
+			deployAppOpts.Strict = true
+			deployAppOpts.OamFiles = []string{
+				"schematics/complex.yaml",
+			}
+			err := deployAppOpts.Execute()
+			Expect(err).Should(MatchError(HavePrefix("4 attributes in the OAM files cannot be translated to ECS as written")))
+		})
+
+		It("application configuration variables should return an error in strict mode", func() {
+			deployAppOpts.Strict = true
+			deployAppOpts.OamFiles = []string{
+				"schematics/webserver.yaml",
+			}
+			err := deployAppOpts.Execute()
+			Expect(err).Should(MatchError(HavePrefix("1 attribute in the OAM files cannot be translated to ECS as written")))
+		})
 	})
 
 	Context("Generate templates", func() {
@@ -159,7 +177,9 @@ var _ = Describe("generate dry-run CloudFormation templates", func() {
 					},
 				},
 			))
-			deployAppOpts.ComponentDeployer = cloudformation.New(session)
+			cf := cloudformation.New(session)
+			deployAppOpts.ComponentDeployer = cf
+			deployAppOpts.DashboardDeployer = cf
 		})
 
 		It("official examples", func() {
@@ -276,5 +296,369 @@ var _ = Describe("generate dry-run CloudFormation templates", func() {
 			Expect(actualTemplate).Should(BeAnExistingFile())
 			Expect(actualTemplate).Should(MatchCloudFormationTemplate(expectedTemplate))
 		})
+
+		It("persistent volumes", func() {
+			deployAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/persistent-volumes.yaml",
+			}
+			err := deployAppOpts.Execute()
+			Expect(err).Should(BeNil())
+
+			actualTemplate, _ := filepath.Abs("oam-ecs-dry-run-results/oam-ecs-persistent-volumes-own-file-system-template.yaml")
+			expectedTemplate, _ := filepath.Abs("../integ-tests/schematics/persistent-volumes.own-file-system.expected.yaml")
+			Expect(actualTemplate).Should(BeAnExistingFile())
+			Expect(actualTemplate).Should(MatchCloudFormationTemplate(expectedTemplate))
+
+			actualTemplate, _ = filepath.Abs("oam-ecs-dry-run-results/oam-ecs-persistent-volumes-environment-file-system-template.yaml")
+			expectedTemplate, _ = filepath.Abs("../integ-tests/schematics/persistent-volumes.environment-file-system.expected.yaml")
+			Expect(actualTemplate).Should(BeAnExistingFile())
+			Expect(actualTemplate).Should(MatchCloudFormationTemplate(expectedTemplate))
+
+			actualTemplate, _ = filepath.Abs("oam-ecs-dry-run-results/oam-ecs-persistent-volumes-host-volumes-template.yaml")
+			expectedTemplate, _ = filepath.Abs("../integ-tests/schematics/persistent-volumes.host-volumes.expected.yaml")
+			Expect(actualTemplate).Should(BeAnExistingFile())
+			Expect(actualTemplate).Should(MatchCloudFormationTemplate(expectedTemplate))
+		})
+
+		It("ephemeral storage", func() {
+			deployAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/ephemeral-storage.yaml",
+			}
+			err := deployAppOpts.Execute()
+			Expect(err).Should(BeNil())
+
+			actualTemplate, _ := filepath.Abs("oam-ecs-dry-run-results/oam-ecs-ephemeral-storage-batch-template.yaml")
+			expectedTemplate, _ := filepath.Abs("../integ-tests/schematics/ephemeral-storage.batch.expected.yaml")
+			Expect(actualTemplate).Should(BeAnExistingFile())
+			Expect(actualTemplate).Should(MatchCloudFormationTemplate(expectedTemplate))
+		})
+
+		It("secrets", func() {
+			deployAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/secrets.yaml",
+			}
+			err := deployAppOpts.Execute()
+			Expect(err).Should(BeNil())
+
+			actualTemplate, _ := filepath.Abs("oam-ecs-dry-run-results/oam-ecs-secrets-orders-template.yaml")
+			expectedTemplate, _ := filepath.Abs("../integ-tests/schematics/secrets.orders.expected.yaml")
+			Expect(actualTemplate).Should(BeAnExistingFile())
+			Expect(actualTemplate).Should(MatchCloudFormationTemplate(expectedTemplate))
+		})
+
+		It("readiness probes", func() {
+			deployAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/readiness.yaml",
+			}
+			err := deployAppOpts.Execute()
+			Expect(err).Should(BeNil())
+
+			actualTemplate, _ := filepath.Abs("oam-ecs-dry-run-results/oam-ecs-readiness-web-template.yaml")
+			expectedTemplate, _ := filepath.Abs("../integ-tests/schematics/readiness.web.expected.yaml")
+			Expect(actualTemplate).Should(BeAnExistingFile())
+			Expect(actualTemplate).Should(MatchCloudFormationTemplate(expectedTemplate))
+		})
+
+		It("worker health probes", func() {
+			deployAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/worker-health.yaml",
+			}
+			err := deployAppOpts.Execute()
+			Expect(err).Should(BeNil())
+
+			actualTemplate, _ := filepath.Abs("oam-ecs-dry-run-results/oam-ecs-worker-health-queue-template.yaml")
+			expectedTemplate, _ := filepath.Abs("../integ-tests/schematics/worker-health.queue.expected.yaml")
+			Expect(actualTemplate).Should(BeAnExistingFile())
+			Expect(actualTemplate).Should(MatchCloudFormationTemplate(expectedTemplate))
+		})
+
+		It("ingress policy", func() {
+			deployAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/ingress-policy.yaml",
+			}
+			err := deployAppOpts.Execute()
+			Expect(err).Should(BeNil())
+
+			actualTemplate, _ := filepath.Abs("oam-ecs-dry-run-results/oam-ecs-ingress-policy-game-template.yaml")
+			expectedTemplate, _ := filepath.Abs("../integ-tests/schematics/ingress-policy.game.expected.yaml")
+			Expect(actualTemplate).Should(BeAnExistingFile())
+			Expect(actualTemplate).Should(MatchCloudFormationTemplate(expectedTemplate))
+		})
+
+		It("internal load balancer", func() {
+			deployAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/internal.yaml",
+			}
+			err := deployAppOpts.Execute()
+			Expect(err).Should(BeNil())
+
+			actualTemplate, _ := filepath.Abs("oam-ecs-dry-run-results/oam-ecs-shop-inventory-template.yaml")
+			expectedTemplate, _ := filepath.Abs("../integ-tests/schematics/internal.inventory.expected.yaml")
+			Expect(actualTemplate).Should(BeAnExistingFile())
+			Expect(actualTemplate).Should(MatchCloudFormationTemplate(expectedTemplate))
+
+			actualTemplate, _ = filepath.Abs("oam-ecs-dry-run-results/oam-ecs-shop-storefront-template.yaml")
+			expectedTemplate, _ = filepath.Abs("../integ-tests/schematics/internal.storefront.expected.yaml")
+			Expect(actualTemplate).Should(BeAnExistingFile())
+			Expect(actualTemplate).Should(MatchCloudFormationTemplate(expectedTemplate))
+		})
+
+		It("service discovery", func() {
+			deployAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/service-discovery.yaml",
+			}
+			err := deployAppOpts.Execute()
+			Expect(err).Should(BeNil())
+
+			actualTemplate, _ := filepath.Abs("oam-ecs-dry-run-results/oam-ecs-search-catalog-template.yaml")
+			expectedTemplate, _ := filepath.Abs("../integ-tests/schematics/service-discovery.catalog.expected.yaml")
+			Expect(actualTemplate).Should(BeAnExistingFile())
+			Expect(actualTemplate).Should(MatchCloudFormationTemplate(expectedTemplate))
+
+			actualTemplate, _ = filepath.Abs("oam-ecs-dry-run-results/oam-ecs-search-indexer-template.yaml")
+			expectedTemplate, _ = filepath.Abs("../integ-tests/schematics/service-discovery.indexer.expected.yaml")
+			Expect(actualTemplate).Should(BeAnExistingFile())
+			Expect(actualTemplate).Should(MatchCloudFormationTemplate(expectedTemplate))
+		})
+
+		It("component output references", func() {
+			deployAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/component-outputs.yaml",
+			}
+			err := deployAppOpts.Execute()
+			Expect(err).Should(BeNil())
+
+			actualTemplate, _ := filepath.Abs("oam-ecs-dry-run-results/oam-ecs-blog-web-template.yaml")
+			expectedTemplate, _ := filepath.Abs("../integ-tests/schematics/component-outputs.web.expected.yaml")
+			Expect(actualTemplate).Should(BeAnExistingFile())
+			Expect(actualTemplate).Should(MatchCloudFormationTemplate(expectedTemplate))
+
+			actualTemplate, _ = filepath.Abs("oam-ecs-dry-run-results/oam-ecs-blog-posts-template.yaml")
+			expectedTemplate, _ = filepath.Abs("../integ-tests/schematics/component-outputs.posts.expected.yaml")
+			Expect(actualTemplate).Should(BeAnExistingFile())
+			Expect(actualTemplate).Should(MatchCloudFormationTemplate(expectedTemplate))
+		})
+
+		It("component output reference cycle should return an error", func() {
+			deployAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/invalid-output-cycle.yaml",
+			}
+			err := deployAppOpts.Execute()
+			Expect(err).Should(MatchError(HavePrefix("Component instances ping -> pong -> ping refer to each other's outputs")))
+		})
+
+		It("sidecars", func() {
+			deployAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/sidecars.yaml",
+			}
+			err := deployAppOpts.Execute()
+			Expect(err).Should(BeNil())
+
+			actualTemplate, _ := filepath.Abs("oam-ecs-dry-run-results/oam-ecs-sidecars-checkout-template.yaml")
+			expectedTemplate, _ := filepath.Abs("../integ-tests/schematics/sidecars.checkout.expected.yaml")
+			Expect(actualTemplate).Should(BeAnExistingFile())
+			Expect(actualTemplate).Should(MatchCloudFormationTemplate(expectedTemplate))
+		})
+
+		It("logging", func() {
+			deployAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/logging.yaml",
+			}
+			err := deployAppOpts.Execute()
+			Expect(err).Should(BeNil())
+
+			actualTemplate, _ := filepath.Abs("oam-ecs-dry-run-results/oam-ecs-logging-payments-template.yaml")
+			expectedTemplate, _ := filepath.Abs("../integ-tests/schematics/logging.payments.expected.yaml")
+			Expect(actualTemplate).Should(BeAnExistingFile())
+			Expect(actualTemplate).Should(MatchCloudFormationTemplate(expectedTemplate))
+
+			actualTemplate, _ = filepath.Abs("oam-ecs-dry-run-results/oam-ecs-logging-audit-template.yaml")
+			expectedTemplate, _ = filepath.Abs("../integ-tests/schematics/logging.audit.expected.yaml")
+			Expect(actualTemplate).Should(BeAnExistingFile())
+			Expect(actualTemplate).Should(MatchCloudFormationTemplate(expectedTemplate))
+
+			actualTemplate, _ = filepath.Abs("oam-ecs-dry-run-results/oam-ecs-logging-indexer-template.yaml")
+			expectedTemplate, _ = filepath.Abs("../integ-tests/schematics/logging.indexer.expected.yaml")
+			Expect(actualTemplate).Should(BeAnExistingFile())
+			Expect(actualTemplate).Should(MatchCloudFormationTemplate(expectedTemplate))
+		})
+
+		It("monitoring", func() {
+			deployAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/monitoring.yaml",
+			}
+			err := deployAppOpts.Execute()
+			Expect(err).Should(BeNil())
+
+			actualTemplate, _ := filepath.Abs("oam-ecs-dry-run-results/oam-ecs-monitoring-api-template.yaml")
+			expectedTemplate, _ := filepath.Abs("../integ-tests/schematics/monitoring.api.expected.yaml")
+			Expect(actualTemplate).Should(BeAnExistingFile())
+			Expect(actualTemplate).Should(MatchCloudFormationTemplate(expectedTemplate))
+
+			actualTemplate, _ = filepath.Abs("oam-ecs-dry-run-results/oam-ecs-monitoring-worker-template.yaml")
+			expectedTemplate, _ = filepath.Abs("../integ-tests/schematics/monitoring.worker.expected.yaml")
+			Expect(actualTemplate).Should(BeAnExistingFile())
+			Expect(actualTemplate).Should(MatchCloudFormationTemplate(expectedTemplate))
+		})
+
+		It("dashboard", func() {
+			deployAppOpts.Dashboard = true
+			deployAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/monitoring.yaml",
+			}
+			err := deployAppOpts.Execute()
+			Expect(err).Should(BeNil())
+
+			actualTemplate, _ := filepath.Abs("oam-ecs-dry-run-results/oam-ecs-monitoring-dashboard-template.yaml")
+			expectedTemplate, _ := filepath.Abs("../integ-tests/schematics/monitoring.dashboard.expected.yaml")
+			Expect(actualTemplate).Should(BeAnExistingFile())
+			Expect(actualTemplate).Should(MatchCloudFormationTemplate(expectedTemplate))
+		})
+
+		It("tracing", func() {
+			deployAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/tracing.yaml",
+			}
+			err := deployAppOpts.Execute()
+			Expect(err).Should(BeNil())
+
+			actualTemplate, _ := filepath.Abs("oam-ecs-dry-run-results/oam-ecs-tracing-api-template.yaml")
+			expectedTemplate, _ := filepath.Abs("../integ-tests/schematics/tracing.api.expected.yaml")
+			Expect(actualTemplate).Should(BeAnExistingFile())
+			Expect(actualTemplate).Should(MatchCloudFormationTemplate(expectedTemplate))
+
+			actualTemplate, _ = filepath.Abs("oam-ecs-dry-run-results/oam-ecs-tracing-worker-template.yaml")
+			expectedTemplate, _ = filepath.Abs("../integ-tests/schematics/tracing.worker.expected.yaml")
+			Expect(actualTemplate).Should(BeAnExistingFile())
+			Expect(actualTemplate).Should(MatchCloudFormationTemplate(expectedTemplate))
+		})
+
+		It("task role permissions", func() {
+			deployAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/permissions.yaml",
+			}
+			err := deployAppOpts.Execute()
+			Expect(err).Should(BeNil())
+
+			actualTemplate, _ := filepath.Abs("oam-ecs-dry-run-results/oam-ecs-permissions-reports-template.yaml")
+			expectedTemplate, _ := filepath.Abs("../integ-tests/schematics/permissions.reports.expected.yaml")
+			Expect(actualTemplate).Should(BeAnExistingFile())
+			Expect(actualTemplate).Should(MatchCloudFormationTemplate(expectedTemplate))
+		})
+	})
+
+	Context("Offline validation", func() {
+		var (
+			validateAppOpts *cli.ValidateAppOpts
+		)
+
+		BeforeAll(func() {
+			os.Chdir("../templates")
+		})
+
+		BeforeEach(func() {
+			validateAppOpts = cli.NewValidateAppOpts()
+		})
+
+		It("official examples", func() {
+			validateAppOpts.OamFiles = []string{
+				"../examples/example-app.yaml",
+				"../examples/server-component.yaml",
+				"../examples/worker-component.yaml",
+			}
+			err := validateAppOpts.Execute()
+			Expect(err).Should(BeNil())
+		})
+
+		It("dropped attributes are warnings", func() {
+			validateAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/complex.yaml",
+			}
+			err := validateAppOpts.Execute()
+			Expect(err).Should(BeNil())
+		})
+
+		It("dropped attributes should return an error in strict mode", func() {
+			validateAppOpts.Strict = true
+			validateAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/complex.yaml",
+			}
+			err := validateAppOpts.Execute()
+			Expect(err).Should(MatchError(HavePrefix("8 problems found in the OAM files and the rendered templates")))
+		})
+
+		It("invalid references should return an error", func() {
+			validateAppOpts.Output = "json"
+			validateAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/invalid-references.yaml",
+			}
+			err := validateAppOpts.Execute()
+			Expect(err).Should(MatchError(HavePrefix("7 problems found in the OAM files and the rendered templates")))
+		})
+
+		It("invalid permissions should return an error", func() {
+			validateAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/invalid-permissions.yaml",
+			}
+			err := validateAppOpts.Execute()
+			Expect(err).Should(MatchError(HavePrefix("6 problems found in the OAM files and the rendered templates")))
+		})
+
+		It("invalid sidecars should return an error", func() {
+			validateAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/invalid-sidecars.yaml",
+			}
+			err := validateAppOpts.Execute()
+			Expect(err).Should(MatchError(HavePrefix("4 problems found in the OAM files and the rendered templates")))
+		})
+
+		It("component output reference cycle should return an error", func() {
+			validateAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/invalid-output-cycle.yaml",
+			}
+			err := validateAppOpts.Execute()
+			Expect(err).Should(MatchError(HavePrefix("1 problem found in the OAM files and the rendered templates")))
+		})
+
+		It("invalid logging should return an error", func() {
+			validateAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/invalid-logging.yaml",
+			}
+			err := validateAppOpts.Execute()
+			Expect(err).Should(MatchError(HavePrefix("5 problems found in the OAM files and the rendered templates")))
+		})
+
+		It("invalid monitoring should return an error", func() {
+			validateAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/invalid-monitoring.yaml",
+			}
+			err := validateAppOpts.Execute()
+			Expect(err).Should(MatchError(HavePrefix("5 problems found in the OAM files and the rendered templates")))
+		})
+
+		It("invalid tracing should return an error", func() {
+			validateAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/invalid-tracing.yaml",
+			}
+			err := validateAppOpts.Execute()
+			Expect(err).Should(MatchError(HavePrefix("1 problem found in the OAM files and the rendered templates")))
+		})
+
+		It("missing component schematic should return an error", func() {
+			validateAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/manually-scaled-frontend.yaml",
+			}
+			err := validateAppOpts.Execute()
+			Expect(err).Should(MatchError(HavePrefix("1 problem found in the OAM files and the rendered templates")))
+		})
+
+		It("unsupported output format should return an error", func() {
+			validateAppOpts.Output = "xml"
+			validateAppOpts.OamFiles = []string{
+				"../integ-tests/schematics/worker.yaml",
+			}
+			err := validateAppOpts.Execute()
+			Expect(err).Should(MatchError(HavePrefix("Output format xml is not supported")))
+		})
 	})
 })
@@ -0,0 +1,59 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cloudwatchlogs provides functionality to read the log events of oam-ecs containers from Amazon CloudWatch Logs.
+package cloudwatchlogs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/types"
+)
+
+// CloudWatchLogs wraps the CloudWatchLogsAPI interface
+type CloudWatchLogs struct {
+	client cloudwatchlogsiface.CloudWatchLogsAPI
+}
+
+// New returns a configured CloudWatch Logs client.
+func New(sess *session.Session) CloudWatchLogs {
+	return CloudWatchLogs{
+		client: cloudwatchlogs.New(sess),
+	}
+}
+
+// LogEvents returns the events of a log group since a given time, interleaved across the log streams by timestamp.
+// The events can be limited to the log streams that start with a prefix, and to the events that match
+// a CloudWatch Logs filter pattern.
+func (c CloudWatchLogs) LogEvents(logGroup string, streamPrefix string, filterPattern string, since time.Time) ([]*types.LogEvent, error) {
+	input := &cloudwatchlogs.FilterLogEventsInput{
+		LogGroupName: aws.String(logGroup),
+		StartTime:    aws.Int64(since.UnixNano() / int64(time.Millisecond)),
+	}
+	if streamPrefix != "" {
+		input.LogStreamNamePrefix = aws.String(streamPrefix)
+	}
+	if filterPattern != "" {
+		input.FilterPattern = aws.String(filterPattern)
+	}
+
+	events := []*types.LogEvent{}
+	err := c.client.FilterLogEventsPages(input, func(page *cloudwatchlogs.FilterLogEventsOutput, lastPage bool) bool {
+		for _, event := range page.Events {
+			timestamp := time.Unix(0, aws.Int64Value(event.Timestamp)*int64(time.Millisecond))
+			events = append(events, types.NewLogEvent(aws.StringValue(event.EventId), timestamp,
+				aws.StringValue(event.LogStreamName), aws.StringValue(event.Message)))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("filter log events of %s: %w", logGroup, err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,168 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ecs provides functionality to retrieve the live status of Amazon ECS services, and to run commands in their tasks.
+package ecs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/types"
+)
+
+// ECS wraps the ECSAPI interface
+type ECS struct {
+	client ecsiface.ECSAPI
+}
+
+// New returns a configured ECS client.
+func New(sess *session.Session) ECS {
+	return ECS{
+		client: ecs.New(sess),
+	}
+}
+
+// DescribeService returns the task counts, the deployments and the most recent events, newest first, of a service
+func (e ECS) DescribeService(cluster string, service string, maxEvents int) (*types.ServiceStatus, error) {
+	out, err := e.client.DescribeServices(&ecs.DescribeServicesInput{
+		Cluster:  aws.String(cluster),
+		Services: []*string{aws.String(service)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describe service %s: %w", service, err)
+	}
+	if len(out.Services) == 0 {
+		reason := "service not found"
+		if len(out.Failures) > 0 {
+			reason = aws.StringValue(out.Failures[0].Reason)
+		}
+		return nil, fmt.Errorf("describe service %s: %s", service, reason)
+	}
+
+	s := out.Services[0]
+	status := &types.ServiceStatus{
+		Name:         aws.StringValue(s.ServiceName),
+		Status:       aws.StringValue(s.Status),
+		DesiredCount: aws.Int64Value(s.DesiredCount),
+		RunningCount: aws.Int64Value(s.RunningCount),
+		PendingCount: aws.Int64Value(s.PendingCount),
+		Deployments:  []*types.ServiceDeployment{},
+		Events:       []*types.ServiceEvent{},
+	}
+
+	for _, deployment := range s.Deployments {
+		status.Deployments = append(status.Deployments, &types.ServiceDeployment{
+			ID:                 aws.StringValue(deployment.Id),
+			Status:             aws.StringValue(deployment.Status),
+			TaskDefinition:     aws.StringValue(deployment.TaskDefinition),
+			RolloutState:       aws.StringValue(deployment.RolloutState),
+			RolloutStateReason: aws.StringValue(deployment.RolloutStateReason),
+			DesiredCount:       aws.Int64Value(deployment.DesiredCount),
+			RunningCount:       aws.Int64Value(deployment.RunningCount),
+			PendingCount:       aws.Int64Value(deployment.PendingCount),
+			FailedTasks:        aws.Int64Value(deployment.FailedTasks),
+			UpdatedAt:          aws.TimeValue(deployment.UpdatedAt),
+		})
+	}
+
+	for i, event := range s.Events {
+		if i == maxEvents {
+			break
+		}
+		status.Events = append(status.Events, &types.ServiceEvent{
+			CreatedAt: aws.TimeValue(event.CreatedAt),
+			Message:   aws.StringValue(event.Message),
+		})
+	}
+
+	return status, nil
+}
+
+// RunningTasks returns the running tasks of a service, given by name or ARN, oldest first
+func (e ECS) RunningTasks(cluster string, service string) ([]*types.Task, error) {
+	var taskARNs []*string
+	err := e.client.ListTasksPages(&ecs.ListTasksInput{
+		Cluster:       aws.String(cluster),
+		ServiceName:   aws.String(service[strings.LastIndex(service, "/")+1:]),
+		DesiredStatus: aws.String(ecs.DesiredStatusRunning),
+	}, func(page *ecs.ListTasksOutput, lastPage bool) bool {
+		taskARNs = append(taskARNs, page.TaskArns...)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list tasks of service %s: %w", service, err)
+	}
+
+	tasks := []*types.Task{}
+	// DescribeTasks takes up to 100 tasks at a time
+	for start := 0; start < len(taskARNs); start += 100 {
+		end := start + 100
+		if end > len(taskARNs) {
+			end = len(taskARNs)
+		}
+		out, err := e.client.DescribeTasks(&ecs.DescribeTasksInput{
+			Cluster: aws.String(cluster),
+			Tasks:   taskARNs[start:end],
+		})
+		if err != nil {
+			return nil, fmt.Errorf("describe tasks of service %s: %w", service, err)
+		}
+
+		for _, task := range out.Tasks {
+			if aws.StringValue(task.LastStatus) != ecs.DesiredStatusRunning {
+				continue
+			}
+			arn := aws.StringValue(task.TaskArn)
+			t := &types.Task{
+				ARN:       arn,
+				ID:        arn[strings.LastIndex(arn, "/")+1:],
+				StartedAt: aws.TimeValue(task.StartedAt),
+			}
+			for _, container := range task.Containers {
+				c := &types.TaskContainer{
+					Name:      aws.StringValue(container.Name),
+					RuntimeID: aws.StringValue(container.RuntimeId),
+				}
+				for _, agent := range container.ManagedAgents {
+					if aws.StringValue(agent.Name) == ecs.ManagedAgentNameExecuteCommandAgent &&
+						aws.StringValue(agent.LastStatus) == ecs.DesiredStatusRunning {
+						c.ExecAgentRunning = true
+					}
+				}
+				t.Containers = append(t.Containers, c)
+			}
+			tasks = append(tasks, t)
+		}
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].StartedAt.Before(tasks[j].StartedAt)
+	})
+	return tasks, nil
+}
+
+// ExecuteCommand starts an interactive ECS Exec session running a command in a container of a task
+func (e ECS) ExecuteCommand(cluster string, taskARN string, container string, command string) (*types.ExecSession, error) {
+	out, err := e.client.ExecuteCommand(&ecs.ExecuteCommandInput{
+		Cluster:     aws.String(cluster),
+		Task:        aws.String(taskARN),
+		Container:   aws.String(container),
+		Command:     aws.String(command),
+		Interactive: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("execute command in container %s of task %s: %w", container, taskARN, err)
+	}
+
+	return &types.ExecSession{
+		SessionID:  aws.StringValue(out.Session.SessionId),
+		StreamURL:  aws.StringValue(out.Session.StreamUrl),
+		TokenValue: aws.StringValue(out.Session.TokenValue),
+	}, nil
+}
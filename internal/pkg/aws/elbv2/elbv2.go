@@ -0,0 +1,57 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package elbv2 provides functionality to retrieve the health of Elastic Load Balancing targets.
+package elbv2
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/types"
+)
+
+// ELBV2 wraps the ELBV2API interface
+type ELBV2 struct {
+	client elbv2iface.ELBV2API
+}
+
+// New returns a configured Elastic Load Balancing client.
+func New(sess *session.Session) ELBV2 {
+	return ELBV2{
+		client: elbv2.New(sess),
+	}
+}
+
+// DescribeTargetHealth returns the health of the targets registered with a target group
+func (e ELBV2) DescribeTargetHealth(targetGroupARN string) (*types.TargetGroupHealth, error) {
+	out, err := e.client.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{
+		TargetGroupArn: aws.String(targetGroupARN),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describe target health of %s: %w", targetGroupARN, err)
+	}
+
+	health := &types.TargetGroupHealth{
+		ARN:     targetGroupARN,
+		Targets: []*types.TargetHealth{},
+	}
+	for _, description := range out.TargetHealthDescriptions {
+		target := &types.TargetHealth{}
+		if description.Target != nil {
+			target.ID = aws.StringValue(description.Target.Id)
+			target.Port = aws.Int64Value(description.Target.Port)
+		}
+		if description.TargetHealth != nil {
+			target.State = aws.StringValue(description.TargetHealth.State)
+			target.Reason = aws.StringValue(description.TargetHealth.Reason)
+			target.Description = aws.StringValue(description.TargetHealth.Description)
+		}
+		health.Targets = append(health.Targets, target)
+	}
+
+	return health, nil
+}
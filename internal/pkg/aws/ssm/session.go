@@ -0,0 +1,70 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ssm provides functionality to connect to Session Manager sessions.
+package ssm
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/types"
+)
+
+const (
+	pluginBinary = "session-manager-plugin"
+	// pluginInstallURL documents how to install the Session Manager plugin
+	pluginInstallURL = "https://docs.aws.amazon.com/systems-manager/latest/userguide/session-manager-working-with-install-plugin.html"
+)
+
+// SessionManagerPlugin connects the terminal to Session Manager sessions with the session-manager-plugin binary
+type SessionManagerPlugin struct {
+	region string
+}
+
+// New returns a Session Manager plugin configured for the session's region.
+func New(sess *session.Session) SessionManagerPlugin {
+	return SessionManagerPlugin{
+		region: aws.StringValue(sess.Config.Region),
+	}
+}
+
+// StartSession connects the terminal's standard input and outputs to a started session on a target,
+// and returns when the session ends
+func (p SessionManagerPlugin) StartSession(execSession *types.ExecSession, target string) error {
+	binary, err := exec.LookPath(pluginBinary)
+	if err != nil {
+		return fmt.Errorf("the %s binary is required to connect to the session, install it following %s", pluginBinary, pluginInstallURL)
+	}
+
+	endpoint, err := endpoints.DefaultResolver().EndpointFor("ssm", p.region)
+	if err != nil {
+		return fmt.Errorf("resolve the SSM endpoint of region %s: %w", p.region, err)
+	}
+	sessionJSON, err := json.Marshal(execSession)
+	if err != nil {
+		return err
+	}
+	parametersJSON, err := json.Marshal(map[string]string{"Target": target})
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(binary, string(sessionJSON), p.region, "StartSession", "", string(parametersJSON), endpoint.URL)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	// The plugin forwards interrupts to the remote command, keep them from ending this process instead
+	signal.Ignore(os.Interrupt)
+	defer signal.Reset(os.Interrupt)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("run %s: %w", pluginBinary, err)
+	}
+	return nil
+}
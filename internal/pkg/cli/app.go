@@ -17,7 +17,13 @@ func BuildAppCmd() *cobra.Command {
 
 	cmd.AddCommand(BuildDeployAppCmd())
 	cmd.AddCommand(BuildShowAppCmd())
+	cmd.AddCommand(BuildListAppCmd())
+	cmd.AddCommand(BuildStatusAppCmd())
+	cmd.AddCommand(BuildLogsAppCmd())
+	cmd.AddCommand(BuildExecAppCmd())
 	cmd.AddCommand(BuildDeleteAppCmd())
+	cmd.AddCommand(BuildValidateAppCmd())
+	cmd.AddCommand(BuildDiffAppCmd())
 
 	return cmd
 }
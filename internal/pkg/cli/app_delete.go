@@ -6,6 +6,7 @@ package cli
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/aws/session"
 	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation"
@@ -21,25 +22,40 @@ const (
 	deleteComponentStart     = "Deleting the infrastructure for the component instance %s."
 	deleteComponentFailed    = "Failed to delete the infrastructure for the component instance %s."
 	deleteComponentSucceeded = "Deleted the infrastructure for component instance %s in CloudFormation stack %s."
+	retainLogsStart          = "Retaining the log group of the component instance %s."
+	retainLogsFailed         = "Failed to retain the log group of the component instance %s."
+	keptLogGroups            = "Kept the log groups of the deleted component instances:"
+	deleteDashboardStart     = "Deleting the dashboard of application %s."
+	deleteDashboardFailed    = "Failed to delete the dashboard of application %s."
+	deleteDashboardSucceeded = "Deleted the dashboard of application %s in CloudFormation stack %s."
 )
 
 type cfComponentDeleter interface {
 	DeleteComponent(component *types.ComponentInput) (*types.Component, error)
+	RetainComponentLogGroup(component *types.ComponentInput) (string, error)
+}
+
+type cfDashboardDeleter interface {
+	DeleteDashboard(dashboard *types.DashboardInput) (*types.Dashboard, error)
 }
 
 // DeleteAppOpts holds the configuration needed to delete an application.
 type DeleteAppOpts struct {
 	// Fields with matching flags
-	OamFile string
+	OamFile  string
+	KeepLogs bool
 
 	prog             progress
 	ComponentDeleter cfComponentDeleter
+	DashboardDeleter cfDashboardDeleter
+	w                io.Writer
 }
 
 // NewDeleteAppOpts initiates the fields to delete an application.
 func NewDeleteAppOpts() *DeleteAppOpts {
 	return &DeleteAppOpts{
 		prog: termprogress.NewSpinner(),
+		w:    log.OutputWriter,
 	}
 }
 
@@ -55,10 +71,22 @@ func (opts *DeleteAppOpts) newComponentInput(application *v1alpha1.ApplicationCo
 	}, nil
 }
 
-func (opts *DeleteAppOpts) deleteComponentInstance(application *v1alpha1.ApplicationConfiguration, componentInstance *v1alpha1.ComponentConfiguration) error {
+// deleteComponentInstance deletes the stack of a component instance. With --keep-logs, the stack is first updated to
+// retain its log group, and the name of the kept log group is returned.
+func (opts *DeleteAppOpts) deleteComponentInstance(application *v1alpha1.ApplicationConfiguration, componentInstance *v1alpha1.ComponentConfiguration) (string, error) {
 	componentInput, err := opts.newComponentInput(application, componentInstance)
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	logGroup := ""
+	if opts.KeepLogs {
+		opts.prog.Start(fmt.Sprintf(retainLogsStart, componentInstance.InstanceName))
+		logGroup, err = opts.ComponentDeleter.RetainComponentLogGroup(componentInput)
+		if err != nil {
+			opts.prog.Stop(log.Serrorf(retainLogsFailed, componentInstance.InstanceName))
+			return "", err
+		}
 	}
 
 	opts.prog.Start(fmt.Sprintf(deleteComponentStart, componentInstance.InstanceName))
@@ -66,11 +94,26 @@ func (opts *DeleteAppOpts) deleteComponentInstance(application *v1alpha1.Applica
 	component, err := opts.ComponentDeleter.DeleteComponent(componentInput)
 	if err != nil {
 		opts.prog.Stop(log.Serrorf(deleteComponentFailed, componentInstance.InstanceName))
-		return err
+		return "", err
 	}
 
 	opts.prog.Stop(log.Ssuccessf(deleteComponentSucceeded, componentInstance.InstanceName, component.StackName))
 
+	return logGroup, nil
+}
+
+// deleteDashboard deletes the dashboard stack of the application, if it was deployed with --dashboard
+func (opts *DeleteAppOpts) deleteDashboard(application *v1alpha1.ApplicationConfiguration) error {
+	opts.prog.Start(fmt.Sprintf(deleteDashboardStart, application.Name))
+
+	dashboard, err := opts.DashboardDeleter.DeleteDashboard(newDashboardInput(application))
+	if err != nil {
+		opts.prog.Stop(log.Serrorf(deleteDashboardFailed, application.Name))
+		return err
+	}
+
+	opts.prog.Stop(log.Ssuccessf(deleteDashboardSucceeded, application.Name, dashboard.StackName))
+
 	return nil
 }
 
@@ -84,15 +127,45 @@ func (opts *DeleteAppOpts) Execute() error {
 		return err
 	}
 
-	// Delete the application components
-	for _, componentInstance := range oamWorkload.ApplicationConfiguration.Spec.Components {
-		err = opts.deleteComponentInstance(oamWorkload.ApplicationConfiguration, &componentInstance)
+	// The dashboard shows the metrics of the application components, so it is deleted first
+	if err := opts.deleteDashboard(oamWorkload.ApplicationConfiguration); err != nil {
+		return err
+	}
+
+	// Delete the application components in the reverse of the deployment order, so that the stacks importing the
+	// exports of other stacks are deleted before them
+	components := []*v1alpha1.ComponentConfiguration{}
+	for i := range oamWorkload.ApplicationConfiguration.Spec.Components {
+		components = append(components, &oamWorkload.ApplicationConfiguration.Spec.Components[i])
+	}
+	if order, orderErr := workload.DeploymentOrder(oamWorkload.ApplicationConfiguration); orderErr == nil {
+		components = components[:0]
+		for i := len(order) - 1; i >= 0; i-- {
+			components = append(components, order[i])
+		}
+	}
+
+	logGroups := []string{}
+	for _, componentInstance := range components {
+		var logGroup string
+		logGroup, err = opts.deleteComponentInstance(oamWorkload.ApplicationConfiguration, componentInstance)
+		if logGroup != "" {
+			logGroups = append(logGroups, logGroup)
+		}
 
 		if err != nil {
 			break
 		}
 	}
 
+	// List the kept log groups, including those of the component instances deleted before any failure
+	if len(logGroups) > 0 {
+		fmt.Fprintln(opts.w, keptLogGroups)
+		for _, logGroup := range logGroups {
+			fmt.Fprintf(opts.w, "  %s\n", logGroup)
+		}
+	}
+
 	return err
 }
 
@@ -102,16 +175,21 @@ func BuildDeleteAppCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "delete",
 		Short: "Delete the application's deployed components",
-		Long:  `Removes the infrastructure for the application defined in an Open Application Model application configuration file.`,
+		Long:  `Removes the infrastructure for the application defined in an Open Application Model application configuration file, including its dashboard.`,
 		Example: `
   Delete the deployed application components, using an application configuration file:
-	$ oam-ecs app delete -f config.yml`,
+	$ oam-ecs app delete -f config.yml
+
+  Delete the deployed application components, but keep their logs:
+	$ oam-ecs app delete -f config.yml --keep-logs`,
 		PreRunE: runCmdE(func(cmd *cobra.Command, args []string) error {
 			session, err := session.Default()
 			if err != nil {
 				return err
 			}
-			opts.ComponentDeleter = cloudformation.New(session)
+			cf := cloudformation.New(session)
+			opts.ComponentDeleter = cf
+			opts.DashboardDeleter = cf
 			return nil
 		}),
 		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
@@ -121,6 +199,7 @@ func BuildDeleteAppCmd() *cobra.Command {
 
 	cmd.Flags().StringVarP(&opts.OamFile, oamFileFlag, oamFileFlagShort, "", appConfigFileFlagDescription)
 	cmd.MarkFlagRequired(oamFileFlag)
+	cmd.Flags().BoolVarP(&opts.KeepLogs, keepLogsFlag, "", false, keepLogsFlagDescription)
 
 	return cmd
 }
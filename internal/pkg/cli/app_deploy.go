@@ -6,6 +6,8 @@ package cli
 
 import (
 	"fmt"
+	"io"
+	"os"
 
 	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/aws/session"
 	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation"
@@ -24,6 +26,17 @@ const (
 	deployComponentStart     = "Deploying infrastructure changes for the component instance %s."
 	deployComponentFailed    = "Failed to deploy infrastructure changes for the component instance %s."
 	deployComponentSucceeded = "Deployed component instance %s in CloudFormation stack %s."
+
+	dryRunDashboardSucceeded = "Wrote infrastructure template to disk for the dashboard of application %s: %s"
+	deployDashboardStart     = "Deploying the dashboard of application %s."
+	deployDashboardFailed    = "Failed to deploy the dashboard of application %s."
+	deployDashboardSucceeded = "Deployed the dashboard of application %s in CloudFormation stack %s."
+
+	// dashboardInstanceName is the component instance name whose stack name would be the dashboard stack name
+	dashboardInstanceName = "dashboard"
+
+	strictValidationFailed    = "%d attributes in the OAM files cannot be translated to ECS as written, use --%s to deploy anyway"
+	strictValidationFailedOne = "1 attribute in the OAM files cannot be translated to ECS as written, use --%s to deploy anyway"
 )
 
 type cfComponentDeployer interface {
@@ -31,28 +44,42 @@ type cfComponentDeployer interface {
 	DryRunComponent(component *types.ComponentInput) (string, error)
 }
 
+type cfDashboardDeployer interface {
+	DeployDashboard(dashboard *types.DashboardInput) (*types.Dashboard, error)
+	DryRunDashboard(dashboard *types.DashboardInput) (string, error)
+}
+
 // DeployAppOpts holds the configuration needed to provision an application.
 type DeployAppOpts struct {
 	// Fields with matching flags
-	OamFiles []string
-	DryRun   bool
+	OamFiles  []string
+	DryRun    bool
+	Strict    bool
+	Warn      bool
+	Dashboard bool
+	Output    string
 
-	prog              progress
-	ComponentDeployer cfComponentDeployer
+	prog                 progress
+	ComponentDeployer    cfComponentDeployer
+	DashboardDeployer    cfDashboardDeployer
+	EnvironmentDescriber cfEnvironmentDescriber
+	w                    io.Writer
+
+	// environmentLogs are the log group defaults of the environment, unknown in dry runs
+	environmentLogs *types.LogSettings
 }
 
 // NewDeployAppOpts initiates the fields to provision an application.
 func NewDeployAppOpts() *DeployAppOpts {
 	return &DeployAppOpts{
-		prog: termprogress.NewSpinner(),
+		Output: types.TableFormat,
+		prog:   termprogress.NewSpinner(),
+		w:      log.OutputWriter,
 	}
 }
 
-func (opts *DeployAppOpts) newComponentInput(application *v1alpha1.ApplicationConfiguration, componentInstance *v1alpha1.ComponentConfiguration, schematic *v1alpha1.ComponentSchematic) (*types.ComponentInput, error) {
-	// TODO validate that following are not set: osType, arch, volume disk, volume sharing policy,
-	// 				container extended resource, container config file, container readiness probe,
-	//				container liveness probe failure threshold/httpGet/tcpSocket
-
+// newComponentInput returns the input to render and deploy the infrastructure of a component instance
+func newComponentInput(application *v1alpha1.ApplicationConfiguration, componentInstance *v1alpha1.ComponentConfiguration, schematics map[string]*v1alpha1.ComponentSchematic) (*types.ComponentInput, error) {
 	ecsSettings := &types.ECSWorkloadSettings{}
 
 	environment := &types.ComponentEnvironment{
@@ -62,14 +89,80 @@ func (opts *DeployAppOpts) newComponentInput(application *v1alpha1.ApplicationCo
 	return &types.ComponentInput{
 		ApplicationConfiguration: application,
 		ComponentConfiguration:   componentInstance,
-		Component:                schematic,
+		Component:                schematics[componentInstance.ComponentName],
+		ComponentSchematics:      schematics,
 		WorkloadSettings:         ecsSettings,
 		Environment:              environment,
 	}, nil
 }
 
-func (opts *DeployAppOpts) dryRunComponentInstance(application *v1alpha1.ApplicationConfiguration, componentInstance *v1alpha1.ComponentConfiguration, schematic *v1alpha1.ComponentSchematic) error {
-	deployComponentInput, err := opts.newComponentInput(application, componentInstance, schematic)
+// newDashboardInput returns the input to render and deploy the dashboard of an application, without its components
+func newDashboardInput(application *v1alpha1.ApplicationConfiguration) *types.DashboardInput {
+	return &types.DashboardInput{
+		ApplicationName: application.Name,
+		Environment: &types.ComponentEnvironment{
+			Name: environmentName,
+		},
+		Components: []*types.DashboardComponent{},
+	}
+}
+
+// deployDashboard deploys or dry-runs the dashboard of the application's component instances. The names of their
+// resources are read from the outputs of the deployed instances, or left as output references in dry runs.
+func (opts *DeployAppOpts) deployDashboard(oamWorkload *workload.OamWorkload, outputs map[string]map[string]string) (*types.Dashboard, error) {
+	application := oamWorkload.ApplicationConfiguration
+	dashboardInput := newDashboardInput(application)
+	for i := range application.Spec.Components {
+		componentInstance := &application.Spec.Components[i]
+		componentInput, err := newComponentInput(application, componentInstance, oamWorkload.ComponentSchematics)
+		if err != nil {
+			return nil, err
+		}
+
+		component := &types.DashboardComponent{
+			InstanceName: componentInstance.InstanceName,
+			LogGroupName: componentInput.LogGroupName(),
+		}
+		if opts.DryRun {
+			component.ServiceName = workload.ComponentOutputReference(componentInstance.InstanceName, types.ServiceNameOutputKey)
+			if workload.HasLoadBalancer(componentInput.Component) {
+				component.LoadBalancerFullName = workload.ComponentOutputReference(componentInstance.InstanceName, types.LoadBalancerOutputKey)
+			}
+		} else {
+			component.ServiceName = outputs[componentInstance.InstanceName][types.ServiceNameOutputKey]
+			component.LoadBalancerFullName = outputs[componentInstance.InstanceName][types.LoadBalancerOutputKey]
+		}
+		dashboardInput.Components = append(dashboardInput.Components, component)
+	}
+
+	if opts.DryRun {
+		file, err := opts.DashboardDeployer.DryRunDashboard(dashboardInput)
+		if err != nil {
+			return nil, err
+		}
+		log.Successln(fmt.Sprintf(dryRunDashboardSucceeded, application.Name, file))
+		return nil, nil
+	}
+
+	opts.prog.Start(fmt.Sprintf(deployDashboardStart, application.Name))
+
+	dashboard, err := opts.DashboardDeployer.DeployDashboard(dashboardInput)
+	if err != nil {
+		opts.prog.Stop(log.Serrorf(deployDashboardFailed, application.Name))
+		return nil, err
+	}
+
+	opts.prog.Stop(log.Ssuccessf(deployDashboardSucceeded, application.Name, dashboard.StackName))
+
+	if opts.Output == types.TableFormat {
+		dashboard.Display(opts.w)
+	}
+
+	return dashboard, nil
+}
+
+func (opts *DeployAppOpts) dryRunComponentInstance(application *v1alpha1.ApplicationConfiguration, componentInstance *v1alpha1.ComponentConfiguration, schematics map[string]*v1alpha1.ComponentSchematic) error {
+	deployComponentInput, err := newComponentInput(application, componentInstance, schematics)
 	if err != nil {
 		return err
 	}
@@ -84,29 +177,59 @@ func (opts *DeployAppOpts) dryRunComponentInstance(application *v1alpha1.Applica
 	return nil
 }
 
-func (opts *DeployAppOpts) deployComponentInstance(application *v1alpha1.ApplicationConfiguration, componentInstance *v1alpha1.ComponentConfiguration, schematic *v1alpha1.ComponentSchematic) error {
-	deployComponentInput, err := opts.newComponentInput(application, componentInstance, schematic)
+func (opts *DeployAppOpts) deployComponentInstance(application *v1alpha1.ApplicationConfiguration, componentInstance *v1alpha1.ComponentConfiguration, schematics map[string]*v1alpha1.ComponentSchematic) (*types.Component, error) {
+	deployComponentInput, err := newComponentInput(application, componentInstance, schematics)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	deployComponentInput.Environment.Logs = opts.environmentLogs
 
 	opts.prog.Start(fmt.Sprintf(deployComponentStart, componentInstance.InstanceName))
 
 	component, err := opts.ComponentDeployer.DeployComponent(deployComponentInput)
 	if err != nil {
 		opts.prog.Stop(log.Serrorf(deployComponentFailed, componentInstance.InstanceName))
-		return err
+		return nil, err
 	}
 
 	opts.prog.Stop(log.Ssuccessf(deployComponentSucceeded, componentInstance.InstanceName, component.StackName))
 
-	component.Display()
+	if opts.Output == types.TableFormat {
+		component.Display(opts.w)
+	}
+
+	return component, nil
+}
 
+// reportDiagnostics logs the diagnostics found in the OAM files. In strict mode, the diagnostics are errors
+// and prevent the deployment, unless warnings are explicitly requested.
+func (opts *DeployAppOpts) reportDiagnostics(diagnostics []*workload.Diagnostic) error {
+	strict := opts.Strict && !opts.Warn
+
+	for _, diagnostic := range diagnostics {
+		if strict {
+			diagnostic.Severity = workload.SeverityError
+			log.Errorln(diagnostic.String())
+		} else {
+			log.Warningln(diagnostic.String())
+		}
+	}
+
+	if strict && len(diagnostics) == 1 {
+		return fmt.Errorf(strictValidationFailedOne, warnFlag)
+	}
+	if strict && len(diagnostics) > 1 {
+		return fmt.Errorf(strictValidationFailed, len(diagnostics), warnFlag)
+	}
 	return nil
 }
 
 // Execute parses the OAM files, translates them into infrastructure definitions, and deploys the infrastructure
 func (opts *DeployAppOpts) Execute() error {
+	if err := types.ValidateFormat(opts.Output); err != nil {
+		return err
+	}
+
 	oamWorkload, err := workload.NewOamWorkload(
 		&workload.OamWorkloadProps{
 			OamFiles: opts.OamFiles,
@@ -124,13 +247,55 @@ func (opts *DeployAppOpts) Execute() error {
 		}
 	}
 
+	// The dashboard stack is named like the stack of a component instance named dashboard
+	if opts.Dashboard {
+		for _, component := range oamWorkload.ApplicationConfiguration.Spec.Components {
+			if component.InstanceName == dashboardInstanceName {
+				return fmt.Errorf("Component instance %s cannot be deployed with --%s, its stack name is the name of the dashboard stack", dashboardInstanceName, dashboardFlag)
+			}
+		}
+	}
+
+	// Report attributes that cannot be translated to ECS as written
+	if err := opts.reportDiagnostics(oamWorkload.Validate()); err != nil {
+		return err
+	}
+
+	// Component instances are deployed after the component instances whose outputs they refer to
+	order, err := workload.DeploymentOrder(oamWorkload.ApplicationConfiguration)
+	if err != nil {
+		return err
+	}
+
 	// Deploy or dry-run the application components
-	for _, componentInstance := range oamWorkload.ApplicationConfiguration.Spec.Components {
-		schematic, _ := oamWorkload.ComponentSchematics[componentInstance.ComponentName]
+	components := []*types.Component{}
+	var outputs map[string]map[string]string
+	if !opts.DryRun {
+		outputs = make(map[string]map[string]string)
+
+		// The log groups of the component instances default to the environment's log settings
+		env, err := opts.EnvironmentDescriber.DescribeEnvironment(&types.EnvironmentInput{})
+		if err != nil {
+			return fmt.Errorf("describe the environment: %w", err)
+		}
+		opts.environmentLogs = env.LogSettings()
+	}
+	for _, componentInstance := range order {
+		var resolvedInstance *v1alpha1.ComponentConfiguration
+		resolvedInstance, err = workload.ResolveComponentOutputs(componentInstance, outputs)
+		if err != nil {
+			break
+		}
+
 		if opts.DryRun {
-			err = opts.dryRunComponentInstance(oamWorkload.ApplicationConfiguration, &componentInstance, schematic)
+			err = opts.dryRunComponentInstance(oamWorkload.ApplicationConfiguration, resolvedInstance, oamWorkload.ComponentSchematics)
 		} else {
-			err = opts.deployComponentInstance(oamWorkload.ApplicationConfiguration, &componentInstance, schematic)
+			var component *types.Component
+			component, err = opts.deployComponentInstance(oamWorkload.ApplicationConfiguration, resolvedInstance, oamWorkload.ComponentSchematics)
+			if component != nil {
+				components = append(components, component)
+				outputs[componentInstance.InstanceName] = component.StackOutputs
+			}
 		}
 
 		if err != nil {
@@ -138,6 +303,18 @@ func (opts *DeployAppOpts) Execute() error {
 		}
 	}
 
+	// The dashboard is deployed once all the component instances are
+	if err == nil && opts.Dashboard {
+		_, err = opts.deployDashboard(oamWorkload, outputs)
+	}
+
+	// Structured output lists the component instances deployed before any failure
+	if !opts.DryRun && opts.Output != types.TableFormat {
+		if marshalErr := types.Marshal(opts.w, opts.Output, components); marshalErr != nil && err == nil {
+			err = marshalErr
+		}
+	}
+
 	return err
 }
 
@@ -150,13 +327,22 @@ func BuildDeployAppCmd() *cobra.Command {
 		Long:  `Provisions (or updates) the Amazon ECS infrastructure for the application defined using the Open Application Model spec. All component schematics and the application configuration file for the application must be provided every time the 'app deploy' command runs (this CLI does not save any state).`,
 		Example: `
   Deploy the application's OAM component schematic files and application configuration file:
-	$ oam-ecs app deploy -f component1.yml,component2.yml,config.yml`,
+	$ oam-ecs app deploy -f component1.yml,component2.yml,config.yml
+
+  Fail if any attribute in the OAM files would be dropped by the translation to ECS:
+	$ oam-ecs app deploy --strict -f component1.yml,component2.yml,config.yml
+
+  Deploy the application with a CloudWatch dashboard of its component instances:
+	$ oam-ecs app deploy --dashboard -f component1.yml,component2.yml,config.yml`,
 		PreRunE: runCmdE(func(cmd *cobra.Command, args []string) error {
 			session, err := session.Default()
 			if err != nil {
 				return err
 			}
-			opts.ComponentDeployer = cloudformation.New(session)
+			cf := cloudformation.New(session)
+			opts.ComponentDeployer = cf
+			opts.EnvironmentDescriber = cf
+			opts.DashboardDeployer = cf
 			return nil
 		}),
 		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
@@ -167,6 +353,10 @@ func BuildDeployAppCmd() *cobra.Command {
 	cmd.Flags().StringSliceVarP(&opts.OamFiles, oamFileFlag, oamFileFlagShort, []string{}, oamFileFlagDescription)
 	cmd.MarkFlagRequired(oamFileFlag)
 	cmd.Flags().BoolVarP(&opts.DryRun, dryRunFlag, "", false, dryRunFlagDescription)
+	cmd.Flags().BoolVarP(&opts.Strict, strictFlag, "", os.Getenv(ciEnvVar) != "", strictFlagDescription)
+	cmd.Flags().BoolVarP(&opts.Warn, warnFlag, "", false, warnFlagDescription)
+	cmd.Flags().BoolVarP(&opts.Dashboard, dashboardFlag, "", false, dashboardFlagDescription)
+	cmd.Flags().StringVarP(&opts.Output, outputFlag, "", types.TableFormat, outputFlagDescription)
 
 	return cmd
 }
@@ -0,0 +1,189 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cli contains the oam-ecs subcommands.
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/aws/session"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/types"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/term/color"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/term/log"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/workload"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+)
+
+const (
+	diffComponentUnchanged = "Component instance %s has no infrastructure changes in CloudFormation stack %s."
+	diffComponentChanged   = "Component instance %s has infrastructure changes in CloudFormation stack %s:"
+	diffComponentNew       = "Component instance %s is not deployed yet, CloudFormation stack %s would be created:"
+	diffOutputsUnresolved  = "Could not resolve the references to component outputs of component instance %s, they are shown as written: %v"
+
+	// diffContextLines is the number of unchanged lines shown around each change
+	diffContextLines = 3
+)
+
+type cfComponentTemplateGetter interface {
+	DeployedComponentTemplate(component *types.ComponentInput) (string, string, error)
+}
+
+// DiffAppOpts holds the configuration needed to compare an application with its deployed infrastructure.
+type DiffAppOpts struct {
+	// Fields with matching flags
+	OamFiles []string
+
+	ComponentRenderer       cfComponentRenderer
+	ComponentTemplateGetter cfComponentTemplateGetter
+	ComponentDescriber      cfComponentDescriber
+	EnvironmentDescriber    cfEnvironmentDescriber
+	w                       io.Writer
+}
+
+// NewDiffAppOpts initiates the fields to compare an application with its deployed infrastructure.
+func NewDiffAppOpts() *DiffAppOpts {
+	return &DiffAppOpts{
+		ComponentRenderer: cloudformation.NewTemplateRenderer(),
+		w:                 log.OutputWriter,
+	}
+}
+
+// writeDiff prints a unified diff of two templates, with removed lines in red and added lines in green
+func (opts *DiffAppOpts) writeDiff(stackName string, deployed string, rendered string) error {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(deployed),
+		B:        difflib.SplitLines(rendered),
+		FromFile: "deployed/" + stackName,
+		ToFile:   "rendered/" + stackName,
+		Context:  diffContextLines,
+	})
+	if err != nil {
+		return err
+	}
+
+	if diff == "" {
+		return nil
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			line = color.BoldUnderline.Sprint(line)
+		case strings.HasPrefix(line, "@@"):
+			line = color.Cyan.Sprint(line)
+		case strings.HasPrefix(line, "+"):
+			line = color.Green.Sprint(line)
+		case strings.HasPrefix(line, "-"):
+			line = color.Red.Sprint(line)
+		}
+		if _, err := fmt.Fprintln(opts.w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Execute renders the template of every component instance, and prints how it differs from the template
+// the component instance's stack was last deployed with
+func (opts *DiffAppOpts) Execute() error {
+	oamWorkload, err := workload.NewOamWorkload(
+		&workload.OamWorkloadProps{
+			OamFiles: opts.OamFiles,
+		})
+	if err != nil {
+		return err
+	}
+
+	application := oamWorkload.ApplicationConfiguration
+	order, err := workload.DeploymentOrder(application)
+	if err != nil {
+		return err
+	}
+
+	// The log groups are rendered with the log settings of the deployed environment, if it exists
+	var environmentLogs *types.LogSettings
+	if env, err := opts.EnvironmentDescriber.DescribeEnvironment(&types.EnvironmentInput{}); err == nil {
+		environmentLogs = env.LogSettings()
+	}
+
+	// References to component outputs are resolved with the outputs of the deployed stacks
+	outputs := make(map[string]map[string]string)
+	for _, componentInstance := range order {
+		resolvedInstance, err := workload.ResolveComponentOutputs(componentInstance, outputs)
+		if err != nil {
+			log.Warningf(diffOutputsUnresolved+"\n", componentInstance.InstanceName, err)
+			resolvedInstance, _ = workload.ResolveComponentOutputs(componentInstance, nil)
+		}
+
+		componentInput, err := newComponentInput(application, resolvedInstance, oamWorkload.ComponentSchematics)
+		if err != nil {
+			return err
+		}
+		componentInput.Environment.Logs = environmentLogs
+		stackName, rendered, err := opts.ComponentRenderer.RenderComponent(componentInput)
+		if err != nil {
+			return err
+		}
+
+		if deployedComponent, err := opts.ComponentDescriber.DescribeComponent(componentInput); err == nil {
+			outputs[componentInstance.InstanceName] = deployedComponent.StackOutputs
+		}
+
+		_, deployed, err := opts.ComponentTemplateGetter.DeployedComponentTemplate(componentInput)
+		if err != nil {
+			var notFoundErr *cloudformation.ErrStackNotFound
+			if !errors.As(err, &notFoundErr) {
+				return err
+			}
+			log.Infof(diffComponentNew+"\n", componentInstance.InstanceName, stackName)
+		} else if deployed == rendered {
+			log.Successf(diffComponentUnchanged+"\n", componentInstance.InstanceName, stackName)
+			continue
+		} else {
+			log.Infof(diffComponentChanged+"\n", componentInstance.InstanceName, stackName)
+		}
+
+		if err := opts.writeDiff(stackName, deployed, rendered); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// BuildDiffAppCmd builds the command for comparing an application with its deployed infrastructure.
+func BuildDiffAppCmd() *cobra.Command {
+	opts := NewDiffAppOpts()
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show the infrastructure changes a deployment would make",
+		Long:  `Renders the infrastructure template of every component instance in the given Open Application Model files, and shows how it differs from the template that the component instance's CloudFormation stack was last deployed with. Does not change any resources.`,
+		Example: `
+  Show the infrastructure changes that deploying the application would make:
+	$ oam-ecs app diff -f component1.yml,component2.yml,config.yml`,
+		PreRunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			session, err := session.Default()
+			if err != nil {
+				return err
+			}
+			cf := cloudformation.New(session)
+			opts.ComponentTemplateGetter = cf
+			opts.ComponentDescriber = cf
+			opts.EnvironmentDescriber = cf
+			return nil
+		}),
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			return opts.Execute()
+		}),
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.OamFiles, oamFileFlag, oamFileFlagShort, []string{}, oamFileFlagDescription)
+	cmd.MarkFlagRequired(oamFileFlag)
+
+	return cmd
+}
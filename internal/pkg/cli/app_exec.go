@@ -0,0 +1,245 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/aws/ecs"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/aws/session"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/aws/ssm"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/types"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/term/prompt"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/workload"
+	"github.com/kballard/go-shellquote"
+	"github.com/oam-dev/oam-go-sdk/apis/core.oam.dev/v1alpha1"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultExecCommand = "/bin/sh"
+
+	execTraitNotBound      = "Component instance %s does not have the exec trait, add it to the component instance and redeploy the application to enable ECS Exec"
+	noRunningTasks         = "Component instance %s has no running tasks"
+	taskNotFound           = "Component instance %s has no running task %s"
+	containerNotFound      = "Task %s has no container %s"
+	execAgentNotRunning    = "ECS Exec is not enabled in container %s of task %s, redeploy the application to replace the tasks started before ECS Exec was enabled"
+	noExecAgentContainer   = "ECS Exec is not enabled in any container of task %s, redeploy the application to replace the tasks started before ECS Exec was enabled"
+	selectTaskMessage      = "Which task of %s would you like to run the command in?"
+	selectContainerMessage = "Which container of task %s would you like to run the command in?"
+)
+
+type ecsTaskExecutor interface {
+	RunningTasks(cluster string, service string) ([]*types.Task, error)
+	ExecuteCommand(cluster string, taskARN string, container string, command string) (*types.ExecSession, error)
+}
+
+type ssmSessionStarter interface {
+	StartSession(execSession *types.ExecSession, target string) error
+}
+
+type selector interface {
+	SelectOne(message string, options []string) (string, error)
+}
+
+// ExecAppOpts holds the configuration needed to run a command in a container of a component instance.
+type ExecAppOpts struct {
+	// Fields with matching flags
+	OamFile   string
+	Component string
+	Container string
+	Task      string
+
+	// Command is the command to run, given after --
+	Command string
+
+	ComponentResourceDescriber cfComponentResourceDescriber
+	TaskExecutor               ecsTaskExecutor
+	SessionStarter             ssmSessionStarter
+	sel                        selector
+}
+
+// NewExecAppOpts initiates the fields to run a command in a container of a component instance.
+func NewExecAppOpts() *ExecAppOpts {
+	return &ExecAppOpts{
+		Command: defaultExecCommand,
+		sel:     prompt.New(),
+	}
+}
+
+// selectTask returns the task given by --task, the only running task, or the task the user chooses
+func (opts *ExecAppOpts) selectTask(tasks []*types.Task) (*types.Task, error) {
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf(noRunningTasks, opts.Component)
+	}
+	if opts.Task != "" {
+		for _, task := range tasks {
+			if task.ID == opts.Task || task.ARN == opts.Task {
+				return task, nil
+			}
+		}
+		return nil, fmt.Errorf(taskNotFound, opts.Component, opts.Task)
+	}
+	if len(tasks) == 1 {
+		return tasks[0], nil
+	}
+
+	options := make([]string, 0, len(tasks))
+	byOption := make(map[string]*types.Task)
+	for _, task := range tasks {
+		option := fmt.Sprintf("%s (started %s)", task.ID, task.StartedAt.Format(time.RFC3339))
+		options = append(options, option)
+		byOption[option] = task
+	}
+	selected, err := opts.sel.SelectOne(fmt.Sprintf(selectTaskMessage, opts.Component), options)
+	if err != nil {
+		return nil, fmt.Errorf("select a task: %w", err)
+	}
+	return byOption[selected], nil
+}
+
+// selectContainer returns the container given by --container, the only container running the ECS Exec agent,
+// or the container the user chooses
+func (opts *ExecAppOpts) selectContainer(task *types.Task) (*types.TaskContainer, error) {
+	if opts.Container != "" {
+		for _, container := range task.Containers {
+			if container.Name != opts.Container {
+				continue
+			}
+			if !container.ExecAgentRunning {
+				return nil, fmt.Errorf(execAgentNotRunning, container.Name, task.ID)
+			}
+			return container, nil
+		}
+		return nil, fmt.Errorf(containerNotFound, task.ID, opts.Container)
+	}
+
+	options := []string{}
+	byName := make(map[string]*types.TaskContainer)
+	for _, container := range task.Containers {
+		if container.ExecAgentRunning {
+			options = append(options, container.Name)
+			byName[container.Name] = container
+		}
+	}
+	switch len(options) {
+	case 0:
+		return nil, fmt.Errorf(noExecAgentContainer, task.ID)
+	case 1:
+		return byName[options[0]], nil
+	}
+	selected, err := opts.sel.SelectOne(fmt.Sprintf(selectContainerMessage, task.ID), options)
+	if err != nil {
+		return nil, fmt.Errorf("select a container: %w", err)
+	}
+	return byName[selected], nil
+}
+
+// Execute finds a running task of the component instance, starts an ECS Exec session running the command
+// in one of its containers, and connects the terminal to the session until the command exits
+func (opts *ExecAppOpts) Execute() error {
+	oamWorkload, err := workload.NewOamWorkload(
+		&workload.OamWorkloadProps{
+			OamFiles: []string{opts.OamFile},
+		})
+	if err != nil {
+		return err
+	}
+
+	application := oamWorkload.ApplicationConfiguration
+	var componentInstance *v1alpha1.ComponentConfiguration
+	for i := range application.Spec.Components {
+		if application.Spec.Components[i].InstanceName == opts.Component {
+			componentInstance = &application.Spec.Components[i]
+		}
+	}
+	if componentInstance == nil {
+		return fmt.Errorf(componentInstanceNotFound, application.Name, opts.Component)
+	}
+	if !workload.HasExecTrait(componentInstance) {
+		return fmt.Errorf(execTraitNotBound, opts.Component)
+	}
+
+	environment := &types.ComponentEnvironment{
+		Name: environmentName,
+	}
+	resources, err := opts.ComponentResourceDescriber.DescribeComponentResources(&types.ComponentInput{
+		ApplicationConfiguration: application,
+		ComponentConfiguration:   componentInstance,
+		Environment:              environment,
+	})
+	if err != nil {
+		return err
+	}
+
+	tasks, err := opts.TaskExecutor.RunningTasks(environment.Name, resources.ServiceARN)
+	if err != nil {
+		return err
+	}
+	task, err := opts.selectTask(tasks)
+	if err != nil {
+		return err
+	}
+	container, err := opts.selectContainer(task)
+	if err != nil {
+		return err
+	}
+
+	execSession, err := opts.TaskExecutor.ExecuteCommand(environment.Name, task.ARN, container.Name, opts.Command)
+	if err != nil {
+		return err
+	}
+	// Session Manager addresses the container as ecs:<cluster>_<task ID>_<container runtime ID>
+	target := fmt.Sprintf("ecs:%s_%s_%s", environment.Name, task.ID, container.RuntimeID)
+	return opts.SessionStarter.StartSession(execSession, target)
+}
+
+// BuildExecAppCmd builds the command for running a command in a container of a component instance.
+func BuildExecAppCmd() *cobra.Command {
+	opts := NewExecAppOpts()
+	cmd := &cobra.Command{
+		Use:   "exec [-- command]",
+		Short: "Run a command in a container of a component instance",
+		Long:  `Runs an interactive command, a shell by default, in a running container of a component instance in an Open Application Model application configuration file, using ECS Exec. The component instance must have the exec trait. Requires the Session Manager plugin for the AWS CLI.`,
+		Example: `
+  Open a shell in the nginx container of the web-front-end component instance:
+	$ oam-ecs app exec -f config.yml --component web-front-end --container nginx
+
+  Run a command in a specific task of the web-front-end component instance:
+	$ oam-ecs app exec -f config.yml --component web-front-end --task 1a2b3c4d5e6f -- cat /etc/nginx/nginx.conf`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 && cmd.ArgsLenAtDash() != 0 {
+				return fmt.Errorf("the command to run must follow --")
+			}
+			return nil
+		},
+		PreRunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			session, err := session.Default()
+			if err != nil {
+				return err
+			}
+			opts.ComponentResourceDescriber = cloudformation.New(session)
+			opts.TaskExecutor = ecs.New(session)
+			opts.SessionStarter = ssm.New(session)
+			return nil
+		}),
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Command = shellquote.Join(args...)
+			}
+			return opts.Execute()
+		}),
+	}
+
+	cmd.Flags().StringVarP(&opts.OamFile, oamFileFlag, oamFileFlagShort, "", appConfigFileFlagDescription)
+	cmd.MarkFlagRequired(oamFileFlag)
+	cmd.Flags().StringVarP(&opts.Component, componentFlag, "", "", componentFlagDescription)
+	cmd.MarkFlagRequired(componentFlag)
+	cmd.Flags().StringVarP(&opts.Container, containerFlag, "", "", execContainerFlagDescription)
+	cmd.Flags().StringVarP(&opts.Task, taskFlag, "", "", taskFlagDescription)
+
+	return cmd
+}
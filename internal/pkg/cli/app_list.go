@@ -0,0 +1,99 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cli contains the oam-ecs subcommands.
+package cli
+
+import (
+	"io"
+
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/aws/session"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/types"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/term/log"
+	termprogress "github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/term/progress"
+	"github.com/spf13/cobra"
+)
+
+const (
+	listAppsStart     = "Retrieving the deployed applications."
+	listAppsFailed    = "Failed to retrieve the deployed applications."
+	listAppsSucceeded = "Retrieved %d deployed applications."
+)
+
+type cfApplicationLister interface {
+	ListApplications() ([]*types.Application, error)
+}
+
+// ListAppOpts holds the configuration needed to list the deployed applications.
+type ListAppOpts struct {
+	// Fields with matching flags
+	Output string
+
+	prog              progress
+	ApplicationLister cfApplicationLister
+	w                 io.Writer
+}
+
+// NewListAppOpts initiates the fields to list the deployed applications.
+func NewListAppOpts() *ListAppOpts {
+	return &ListAppOpts{
+		Output: types.TableFormat,
+		prog:   termprogress.NewSpinner(),
+		w:      log.OutputWriter,
+	}
+}
+
+// Execute finds the component instance stacks of all applications and displays them by application and environment
+func (opts *ListAppOpts) Execute() error {
+	if err := types.ValidateFormat(opts.Output); err != nil {
+		return err
+	}
+
+	opts.prog.Start(listAppsStart)
+
+	applications, err := opts.ApplicationLister.ListApplications()
+	if err != nil {
+		opts.prog.Stop(log.Serror(listAppsFailed))
+		return err
+	}
+
+	opts.prog.Stop(log.Ssuccessf(listAppsSucceeded, len(applications)))
+
+	if opts.Output == types.TableFormat {
+		types.DisplayApplications(opts.w, applications)
+		return nil
+	}
+	return types.Marshal(opts.w, opts.Output, applications)
+}
+
+// BuildListAppCmd builds the command for listing the deployed applications.
+func BuildListAppCmd() *cobra.Command {
+	opts := NewListAppOpts()
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the deployed applications",
+		Long:  `Finds the CloudFormation stacks of the component instances deployed by oam-ecs in the region, and lists them by application and environment with the number of component instances, their aggregate status and the last deployment time. No OAM files are needed.`,
+		Example: `
+  List the deployed applications:
+	$ oam-ecs app list
+
+  List the component instances of the deployed applications as JSON:
+	$ oam-ecs app list --output json`,
+		PreRunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			session, err := session.Default()
+			if err != nil {
+				return err
+			}
+			opts.ApplicationLister = cloudformation.New(session)
+			return nil
+		}),
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			return opts.Execute()
+		}),
+	}
+
+	cmd.Flags().StringVarP(&opts.Output, outputFlag, "", types.TableFormat, outputFlagDescription)
+
+	return cmd
+}
@@ -0,0 +1,208 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cli contains the oam-ecs subcommands.
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/aws/cloudwatchlogs"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/aws/session"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/types"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/term/color"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/term/log"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/workload"
+	fatihcolor "github.com/fatih/color"
+	"github.com/oam-dev/oam-go-sdk/apis/core.oam.dev/v1alpha1"
+	"github.com/spf13/cobra"
+)
+
+const (
+	componentInstanceNotFound = "Application configuration %s has no component instance %s"
+	invalidSince              = "Could not parse --%s %s as a duration, such as 30m or 2h: %w"
+
+	defaultLogsSince = "10m"
+	// followInterval is the time between polls for new log events with --follow
+	followInterval = 2 * time.Second
+	// taskIDDisplayLength is the number of characters of the task ID shown before each log line
+	taskIDDisplayLength = 8
+)
+
+// containerColors are assigned to the containers in the order in which their first log event is printed
+var containerColors = []*fatihcolor.Color{color.Cyan, color.Magenta, color.Blue, color.Grey, color.Red}
+
+type logEventsReader interface {
+	LogEvents(logGroup string, streamPrefix string, filterPattern string, since time.Time) ([]*types.LogEvent, error)
+}
+
+// LogsAppOpts holds the configuration needed to read the logs of a component instance.
+type LogsAppOpts struct {
+	// Fields with matching flags
+	OamFile   string
+	Component string
+	Container string
+	Since     string
+	Follow    bool
+	Filter    string
+	Output    string
+
+	LogEventsReader logEventsReader
+	w               io.Writer
+	colors          map[string]*fatihcolor.Color
+}
+
+// NewLogsAppOpts initiates the fields to read the logs of a component instance.
+func NewLogsAppOpts() *LogsAppOpts {
+	return &LogsAppOpts{
+		Since:  defaultLogsSince,
+		Output: textOutput,
+		w:      log.OutputWriter,
+		colors: make(map[string]*fatihcolor.Color),
+	}
+}
+
+// write prints a log event as a line of JSON, or as text prefixed with its container and task in the container's color
+func (opts *LogsAppOpts) write(event *types.LogEvent) error {
+	if opts.Output == jsonOutput {
+		out, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(opts.w, string(out))
+		return err
+	}
+
+	c, ok := opts.colors[event.Container]
+	if !ok {
+		c = containerColors[len(opts.colors)%len(containerColors)]
+		opts.colors[event.Container] = c
+	}
+	task := event.TaskID
+	if len(task) > taskIDDisplayLength {
+		task = task[:taskIDDisplayLength]
+	}
+	_, err := fmt.Fprintf(opts.w, "%s %s %s\n", c.Sprintf("%s/%s", event.Container, task),
+		event.Timestamp.Format(time.RFC3339), event.Message)
+	return err
+}
+
+// Execute reads the log events of a component instance's containers, and keeps polling for new events with --follow
+func (opts *LogsAppOpts) Execute() error {
+	if opts.Output != textOutput && opts.Output != jsonOutput {
+		return fmt.Errorf(invalidOutputFormat, opts.Output, textOutput, jsonOutput)
+	}
+	since, err := time.ParseDuration(opts.Since)
+	if err != nil {
+		return fmt.Errorf(invalidSince, sinceFlag, opts.Since, err)
+	}
+
+	oamWorkload, err := workload.NewOamWorkload(
+		&workload.OamWorkloadProps{
+			OamFiles: []string{opts.OamFile},
+		})
+	if err != nil {
+		return err
+	}
+
+	application := oamWorkload.ApplicationConfiguration
+	var componentInstance *v1alpha1.ComponentConfiguration
+	for i := range application.Spec.Components {
+		if application.Spec.Components[i].InstanceName == opts.Component {
+			componentInstance = &application.Spec.Components[i]
+		}
+	}
+	if componentInstance == nil {
+		return fmt.Errorf(componentInstanceNotFound, application.Name, opts.Component)
+	}
+
+	componentInput := &types.ComponentInput{
+		ApplicationConfiguration: application,
+		ComponentConfiguration:   componentInstance,
+		Environment: &types.ComponentEnvironment{
+			Name: environmentName,
+		},
+	}
+	streamPrefix := types.LogStreamPrefix + "/"
+	if opts.Container != "" {
+		streamPrefix = fmt.Sprintf("%s/%s/", types.LogStreamPrefix, opts.Container)
+	}
+
+	// Events at the timestamp of the last printed event are read again by the next poll, skip those already printed
+	start := time.Now().Add(-since)
+	printed := make(map[string]bool)
+	for {
+		events, err := opts.LogEventsReader.LogEvents(componentInput.LogGroupName(), streamPrefix, opts.Filter, start)
+		if err != nil {
+			return err
+		}
+		sort.SliceStable(events, func(i, j int) bool {
+			return events[i].Timestamp.Before(events[j].Timestamp)
+		})
+
+		for _, event := range events {
+			if printed[event.ID] {
+				continue
+			}
+			if err := opts.write(event); err != nil {
+				return err
+			}
+			if event.Timestamp.After(start) {
+				start = event.Timestamp
+				printed = make(map[string]bool)
+			}
+			printed[event.ID] = true
+		}
+
+		if !opts.Follow {
+			return nil
+		}
+		time.Sleep(followInterval)
+	}
+}
+
+// BuildLogsAppCmd builds the command for reading the logs of a component instance.
+func BuildLogsAppCmd() *cobra.Command {
+	opts := NewLogsAppOpts()
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Show the logs of a component instance",
+		Long:  `Reads the CloudWatch Logs events written by the containers of a component instance in an Open Application Model application configuration file, interleaved by timestamp.`,
+		Example: `
+  Show the last hour of logs of the web-front-end component instance:
+	$ oam-ecs app logs -f config.yml --component web-front-end --since 1h
+
+  Follow the error logs of the nginx container of the web-front-end component instance:
+	$ oam-ecs app logs -f config.yml --component web-front-end --container nginx --filter ERROR --follow
+
+  Print the logs as JSON lines:
+	$ oam-ecs app logs -f config.yml --component web-front-end --output json`,
+		PreRunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			session, err := session.Default()
+			if err != nil {
+				return err
+			}
+			opts.LogEventsReader = cloudwatchlogs.New(session)
+			return nil
+		}),
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			return opts.Execute()
+		}),
+	}
+
+	cmd.Flags().StringVarP(&opts.OamFile, oamFileFlag, oamFileFlagShort, "", appConfigFileFlagDescription)
+	cmd.MarkFlagRequired(oamFileFlag)
+	cmd.Flags().StringVarP(&opts.Component, componentFlag, "", "", componentFlagDescription)
+	cmd.MarkFlagRequired(componentFlag)
+	cmd.Flags().StringVarP(&opts.Container, containerFlag, "", "", containerFlagDescription)
+	cmd.Flags().StringVarP(&opts.Since, sinceFlag, "", defaultLogsSince, sinceFlagDescription)
+	cmd.Flags().BoolVarP(&opts.Follow, followFlag, "", false, followFlagDescription)
+	cmd.Flags().StringVarP(&opts.Filter, filterFlag, "", "", filterFlagDescription)
+	cmd.Flags().StringVarP(&opts.Output, outputFlag, "", textOutput, logsOutputFlagDescription)
+
+	return cmd
+}
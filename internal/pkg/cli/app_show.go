@@ -5,7 +5,9 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"io"
 
 	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/aws/session"
 	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation"
@@ -21,25 +23,35 @@ const (
 	showComponentStart     = "Retrieving the infrastructure information for the component instance %s."
 	showComponentFailed    = "Failed to retrieve the infrastructure information for the component instance %s."
 	showComponentSucceeded = "Retrieved the infrastructure information for component instance %s in CloudFormation stack %s."
+	showDashboard          = "Dashboard: %s\n"
 )
 
 type cfComponentDescriber interface {
 	DescribeComponent(component *types.ComponentInput) (*types.Component, error)
 }
 
+type cfDashboardDescriber interface {
+	DescribeDashboard(dashboard *types.DashboardInput) (*types.Dashboard, error)
+}
+
 // ShowAppOpts holds the configuration needed to describe an application.
 type ShowAppOpts struct {
 	// Fields with matching flags
 	OamFile string
+	Output  string
 
 	prog               progress
 	ComponentDescriber cfComponentDescriber
+	DashboardDescriber cfDashboardDescriber
+	w                  io.Writer
 }
 
 // NewShowAppOpts initiates the fields to describe an application.
 func NewShowAppOpts() *ShowAppOpts {
 	return &ShowAppOpts{
-		prog: termprogress.NewSpinner(),
+		Output: types.TableFormat,
+		prog:   termprogress.NewSpinner(),
+		w:      log.OutputWriter,
 	}
 }
 
@@ -55,10 +67,10 @@ func (opts *ShowAppOpts) newComponentInput(application *v1alpha1.ApplicationConf
 	}, nil
 }
 
-func (opts *ShowAppOpts) showComponentInstance(application *v1alpha1.ApplicationConfiguration, componentInstance *v1alpha1.ComponentConfiguration) error {
+func (opts *ShowAppOpts) showComponentInstance(application *v1alpha1.ApplicationConfiguration, componentInstance *v1alpha1.ComponentConfiguration) (*types.Component, error) {
 	componentInput, err := opts.newComponentInput(application, componentInstance)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	opts.prog.Start(fmt.Sprintf(showComponentStart, componentInstance.InstanceName))
@@ -66,18 +78,24 @@ func (opts *ShowAppOpts) showComponentInstance(application *v1alpha1.Application
 	component, err := opts.ComponentDescriber.DescribeComponent(componentInput)
 	if err != nil {
 		opts.prog.Stop(log.Serrorf(showComponentFailed, componentInstance.InstanceName))
-		return err
+		return nil, err
 	}
 
 	opts.prog.Stop(log.Ssuccessf(showComponentSucceeded, componentInstance.InstanceName, component.StackName))
 
-	component.Display()
+	if opts.Output == types.TableFormat {
+		component.Display(opts.w)
+	}
 
-	return nil
+	return component, nil
 }
 
 // Execute parses the OAM files and shows the infrastructure for the application configuration
 func (opts *ShowAppOpts) Execute() error {
+	if err := types.ValidateFormat(opts.Output); err != nil {
+		return err
+	}
+
 	oamWorkload, err := workload.NewOamWorkload(
 		&workload.OamWorkloadProps{
 			OamFiles: []string{opts.OamFile},
@@ -87,12 +105,33 @@ func (opts *ShowAppOpts) Execute() error {
 	}
 
 	// Show the application components
+	components := []*types.Component{}
 	for _, componentInstance := range oamWorkload.ApplicationConfiguration.Spec.Components {
-		err = opts.showComponentInstance(oamWorkload.ApplicationConfiguration, &componentInstance)
+		var component *types.Component
+		component, err = opts.showComponentInstance(oamWorkload.ApplicationConfiguration, &componentInstance)
 
 		if err != nil {
 			break
 		}
+		components = append(components, component)
+	}
+
+	// Link the dashboard of the application, if it was deployed with --dashboard
+	if err == nil && opts.Output == types.TableFormat {
+		var dashboard *types.Dashboard
+		dashboard, err = opts.DashboardDescriber.DescribeDashboard(newDashboardInput(oamWorkload.ApplicationConfiguration))
+		var notFoundErr *cloudformation.ErrStackNotFound
+		if errors.As(err, &notFoundErr) {
+			err = nil
+		} else if err == nil {
+			fmt.Fprintf(opts.w, showDashboard, dashboard.URL)
+		}
+	}
+
+	if opts.Output != types.TableFormat {
+		if marshalErr := types.Marshal(opts.w, opts.Output, components); marshalErr != nil && err == nil {
+			err = marshalErr
+		}
 	}
 
 	return err
@@ -107,13 +146,18 @@ func BuildShowAppCmd() *cobra.Command {
 		Long:  `Retrieves and displays the attributes of the infrastructure for the application defined in an Open Application Model application configuration file.`,
 		Example: `
   Show the deployed application components, using an application configuration file:
-	$ oam-ecs app show -f config.yml`,
+	$ oam-ecs app show -f config.yml
+
+  Show the endpoints of the deployed application components as JSON:
+	$ oam-ecs app show -f config.yml --output json | jq -r '.[].endpoints[] | .url // .address'`,
 		PreRunE: runCmdE(func(cmd *cobra.Command, args []string) error {
 			session, err := session.Default()
 			if err != nil {
 				return err
 			}
-			opts.ComponentDescriber = cloudformation.New(session)
+			cf := cloudformation.New(session)
+			opts.ComponentDescriber = cf
+			opts.DashboardDescriber = cf
 			return nil
 		}),
 		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
@@ -123,6 +167,7 @@ func BuildShowAppCmd() *cobra.Command {
 
 	cmd.Flags().StringVarP(&opts.OamFile, oamFileFlag, oamFileFlagShort, "", appConfigFileFlagDescription)
 	cmd.MarkFlagRequired(oamFileFlag)
+	cmd.Flags().StringVarP(&opts.Output, outputFlag, "", types.TableFormat, outputFlagDescription)
 
 	return cmd
 }
@@ -0,0 +1,247 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cli contains the oam-ecs subcommands.
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/aws/ecs"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/aws/elbv2"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/aws/session"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/types"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/term/cursor"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/term/log"
+	termprogress "github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/term/progress"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/workload"
+	"github.com/oam-dev/oam-go-sdk/apis/core.oam.dev/v1alpha1"
+	"github.com/spf13/cobra"
+)
+
+const (
+	statusComponentStart     = "Retrieving the status of the component instance %s."
+	statusComponentFailed    = "Failed to retrieve the status of the component instance %s."
+	statusComponentSucceeded = "Retrieved the status of the component instance %s."
+
+	watchRequiresTable = "--%s only supports the %s output format"
+	watchFooter        = "Refreshed at %s, press Ctrl+C to stop.\n"
+
+	// statusMaxEvents is the number of recent service events shown for each component instance
+	statusMaxEvents = 5
+	// watchInterval is the time between refreshes of the status with --watch
+	watchInterval = 5 * time.Second
+)
+
+type cfComponentResourceDescriber interface {
+	DescribeComponentResources(component *types.ComponentInput) (*types.ComponentResources, error)
+}
+
+type ecsServiceDescriber interface {
+	DescribeService(cluster string, service string, maxEvents int) (*types.ServiceStatus, error)
+}
+
+type elbTargetHealthDescriber interface {
+	DescribeTargetHealth(targetGroupARN string) (*types.TargetGroupHealth, error)
+}
+
+type eraser interface {
+	Up(n int)
+	Down(n int)
+	EraseLine()
+}
+
+// StatusAppOpts holds the configuration needed to show the live status of an application.
+type StatusAppOpts struct {
+	// Fields with matching flags
+	OamFile string
+	Output  string
+	Watch   bool
+
+	prog                       progress
+	cur                        eraser
+	ComponentResourceDescriber cfComponentResourceDescriber
+	ServiceDescriber           ecsServiceDescriber
+	TargetHealthDescriber      elbTargetHealthDescriber
+	w                          io.Writer
+}
+
+// NewStatusAppOpts initiates the fields to show the status of an application.
+func NewStatusAppOpts() *StatusAppOpts {
+	return &StatusAppOpts{
+		Output: types.TableFormat,
+		prog:   termprogress.NewSpinner(),
+		cur:    cursor.New(),
+		w:      log.OutputWriter,
+	}
+}
+
+// componentStatus finds the ECS service and target groups of a component instance, and retrieves their status
+func (opts *StatusAppOpts) componentStatus(application *v1alpha1.ApplicationConfiguration, componentInstance *v1alpha1.ComponentConfiguration) (*types.ComponentStatus, error) {
+	environment := &types.ComponentEnvironment{
+		Name: environmentName,
+	}
+	componentInput := &types.ComponentInput{
+		ApplicationConfiguration: application,
+		ComponentConfiguration:   componentInstance,
+		Environment:              environment,
+	}
+
+	resources, err := opts.ComponentResourceDescriber.DescribeComponentResources(componentInput)
+	if err != nil {
+		return nil, err
+	}
+
+	service, err := opts.ServiceDescriber.DescribeService(environment.Name, resources.ServiceARN, statusMaxEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &types.ComponentStatus{
+		InstanceName: componentInstance.InstanceName,
+		StackName:    resources.StackName,
+		Service:      service,
+		TargetGroups: []*types.TargetGroupHealth{},
+	}
+	for _, targetGroupARN := range resources.TargetGroupARNs {
+		health, err := opts.TargetHealthDescriber.DescribeTargetHealth(targetGroupARN)
+		if err != nil {
+			return nil, err
+		}
+		status.TargetGroups = append(status.TargetGroups, health)
+	}
+
+	return status, nil
+}
+
+// statuses retrieves the status of every component instance of the application, with progress updates unless watching
+func (opts *StatusAppOpts) statuses(application *v1alpha1.ApplicationConfiguration) ([]*types.ComponentStatus, error) {
+	statuses := []*types.ComponentStatus{}
+	for i := range application.Spec.Components {
+		componentInstance := &application.Spec.Components[i]
+		if !opts.Watch {
+			opts.prog.Start(fmt.Sprintf(statusComponentStart, componentInstance.InstanceName))
+		}
+
+		status, err := opts.componentStatus(application, componentInstance)
+		if err != nil {
+			if !opts.Watch {
+				opts.prog.Stop(log.Serrorf(statusComponentFailed, componentInstance.InstanceName))
+			}
+			return nil, err
+		}
+
+		if !opts.Watch {
+			opts.prog.Stop(log.Ssuccessf(statusComponentSucceeded, componentInstance.InstanceName))
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// erase clears the given number of lines above the cursor, and moves the cursor to the first of them
+func (opts *StatusAppOpts) erase(lines int) {
+	if lines == 0 {
+		return
+	}
+	opts.cur.Up(lines)
+	for i := 0; i < lines; i++ {
+		opts.cur.EraseLine()
+		opts.cur.Down(1)
+	}
+	opts.cur.Up(lines)
+}
+
+// Execute parses the application configuration and shows the live status of its component instances
+func (opts *StatusAppOpts) Execute() error {
+	if err := types.ValidateFormat(opts.Output); err != nil {
+		return err
+	}
+	if opts.Watch && opts.Output != types.TableFormat {
+		return fmt.Errorf(watchRequiresTable, watchFlag, types.TableFormat)
+	}
+
+	oamWorkload, err := workload.NewOamWorkload(
+		&workload.OamWorkloadProps{
+			OamFiles: []string{opts.OamFile},
+		})
+	if err != nil {
+		return err
+	}
+
+	if !opts.Watch {
+		statuses, err := opts.statuses(oamWorkload.ApplicationConfiguration)
+		if err != nil {
+			return err
+		}
+		if opts.Output != types.TableFormat {
+			return types.Marshal(opts.w, opts.Output, statuses)
+		}
+		for _, status := range statuses {
+			status.Display(opts.w)
+		}
+		return nil
+	}
+
+	// Refresh the status in place until interrupted
+	lines := 0
+	for {
+		statuses, err := opts.statuses(oamWorkload.ApplicationConfiguration)
+		if err != nil {
+			return err
+		}
+
+		var b bytes.Buffer
+		for _, status := range statuses {
+			status.Display(&b)
+		}
+		fmt.Fprintf(&b, watchFooter, time.Now().Format(time.RFC3339))
+
+		opts.erase(lines)
+		fmt.Fprint(opts.w, b.String())
+		lines = strings.Count(b.String(), "\n")
+
+		time.Sleep(watchInterval)
+	}
+}
+
+// BuildStatusAppCmd builds the command for showing the live status of an application.
+func BuildStatusAppCmd() *cobra.Command {
+	opts := NewStatusAppOpts()
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the live status of the application's components",
+		Long:  `Retrieves and displays the task counts, deployment rollout state and recent events of the ECS service of each component instance in an Open Application Model application configuration file, and the health of the load balancer targets.`,
+		Example: `
+  Show the status of the application components, using an application configuration file:
+	$ oam-ecs app status -f config.yml
+
+  Refresh the status of the application components every few seconds:
+	$ oam-ecs app status -f config.yml --watch`,
+		PreRunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			session, err := session.Default()
+			if err != nil {
+				return err
+			}
+			opts.ComponentResourceDescriber = cloudformation.New(session)
+			opts.ServiceDescriber = ecs.New(session)
+			opts.TargetHealthDescriber = elbv2.New(session)
+			return nil
+		}),
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			return opts.Execute()
+		}),
+	}
+
+	cmd.Flags().StringVarP(&opts.OamFile, oamFileFlag, oamFileFlagShort, "", appConfigFileFlagDescription)
+	cmd.MarkFlagRequired(oamFileFlag)
+	cmd.Flags().StringVarP(&opts.Output, outputFlag, "", types.TableFormat, outputFlagDescription)
+	cmd.Flags().BoolVarP(&opts.Watch, watchFlag, "", false, watchFlagDescription)
+
+	return cmd
+}
@@ -0,0 +1,197 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/lint"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/types"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/term/log"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/workload"
+	"github.com/spf13/cobra"
+)
+
+const (
+	templateKind                 = "Template"
+	applicationConfigurationKind = "ApplicationConfiguration"
+
+	textOutput = "text"
+	jsonOutput = "json"
+
+	validateAppSucceeded = "No problems found in the OAM files and the rendered templates."
+	validateAppFailed    = "%d problems found in the OAM files and the rendered templates"
+	validateAppFailedOne = "1 problem found in the OAM files and the rendered templates"
+	invalidOutputFormat  = "Output format %s is not supported, use %s or %s"
+)
+
+type cfComponentRenderer interface {
+	RenderComponent(component *types.ComponentInput) (string, string, error)
+}
+
+// ValidateAppOpts holds the configuration needed to validate an application offline.
+type ValidateAppOpts struct {
+	// Fields with matching flags
+	OamFiles []string
+	Output   string
+	Strict   bool
+
+	ComponentRenderer cfComponentRenderer
+	w                 io.Writer
+}
+
+// NewValidateAppOpts initiates the fields to validate an application.
+func NewValidateAppOpts() *ValidateAppOpts {
+	return &ValidateAppOpts{
+		Output:            textOutput,
+		ComponentRenderer: cloudformation.NewTemplateRenderer(),
+		w:                 log.OutputWriter,
+	}
+}
+
+// renderDiagnostics renders the template of every component instance and checks it,
+// returns the problems as diagnostics on the rendered template
+func (opts *ValidateAppOpts) renderDiagnostics(oamWorkload *workload.OamWorkload) []*workload.Diagnostic {
+	result := []*workload.Diagnostic{}
+	application := oamWorkload.ApplicationConfiguration
+
+	for i := range application.Spec.Components {
+		componentInstance := &application.Spec.Components[i]
+
+		componentInput, err := newComponentInput(application, componentInstance, oamWorkload.ComponentSchematics)
+		if err == nil {
+			var stackName, template string
+			stackName, template, err = opts.ComponentRenderer.RenderComponent(componentInput)
+			if err == nil {
+				for _, finding := range lint.Template(template) {
+					result = append(result, &workload.Diagnostic{
+						Severity: workload.SeverityError,
+						Kind:     templateKind,
+						Name:     stackName,
+						Path:     finding.Path,
+						Message:  finding.Message,
+					})
+				}
+				continue
+			}
+		}
+
+		result = append(result, &workload.Diagnostic{
+			Severity: workload.SeverityError,
+			File:     oamWorkload.ApplicationConfigurationFile,
+			Kind:     applicationConfigurationKind,
+			Name:     application.Name,
+			Path:     fmt.Sprintf("spec.components[%d]", i),
+			Message:  fmt.Sprintf("Could not render the template of component instance %s: %v", componentInstance.InstanceName, err),
+		})
+	}
+
+	return result
+}
+
+// report writes the diagnostics in the requested format
+func (opts *ValidateAppOpts) report(diagnostics []*workload.Diagnostic) error {
+	if opts.Output == jsonOutput {
+		out, err := json.MarshalIndent(diagnostics, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(opts.w, string(out))
+		return err
+	}
+
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == workload.SeverityError {
+			log.Errorln(diagnostic.String())
+		} else {
+			log.Warningln(diagnostic.String())
+		}
+	}
+	return nil
+}
+
+// Execute parses the OAM files, checks them and the templates rendered from them, and reports the problems.
+// Does not call AWS.
+func (opts *ValidateAppOpts) Execute() error {
+	if opts.Output != textOutput && opts.Output != jsonOutput {
+		return fmt.Errorf(invalidOutputFormat, opts.Output, textOutput, jsonOutput)
+	}
+
+	oamWorkload, err := workload.NewOamWorkload(
+		&workload.OamWorkloadProps{
+			OamFiles: opts.OamFiles,
+		})
+	if err != nil {
+		return err
+	}
+
+	diagnostics := oamWorkload.ValidateReferences()
+	referenceErrors := 0
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == workload.SeverityError {
+			referenceErrors++
+		}
+	}
+	// Templates can only be rendered once the component instances and their schematics fit together
+	if referenceErrors == 0 {
+		diagnostics = append(diagnostics, opts.renderDiagnostics(oamWorkload)...)
+	}
+	diagnostics = append(diagnostics, oamWorkload.Validate()...)
+
+	failed := 0
+	for _, diagnostic := range diagnostics {
+		if opts.Strict {
+			diagnostic.Severity = workload.SeverityError
+		}
+		if diagnostic.Severity == workload.SeverityError {
+			failed++
+		}
+	}
+
+	if err := opts.report(diagnostics); err != nil {
+		return err
+	}
+
+	if failed == 1 {
+		return errors.New(validateAppFailedOne)
+	}
+	if failed > 1 {
+		return fmt.Errorf(validateAppFailed, failed)
+	}
+	if opts.Output == textOutput {
+		log.Successln(validateAppSucceeded)
+	}
+	return nil
+}
+
+// BuildValidateAppCmd builds the command for validating an application without AWS credentials.
+func BuildValidateAppCmd() *cobra.Command {
+	opts := NewValidateAppOpts()
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate the application offline",
+		Long:  `Checks the OAM component schematics and application configuration, renders the infrastructure template of every component instance and checks the templates, without calling AWS. Exits with an error if any problem is found.`,
+		Example: `
+  Validate the application's OAM component schematic files and application configuration file:
+	$ oam-ecs app validate -f component1.yml,component2.yml,config.yml
+
+  Print the problems as JSON, and fail on attributes that cannot be translated to ECS as written:
+	$ oam-ecs app validate --strict --output json -f component1.yml,component2.yml,config.yml`,
+		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			return opts.Execute()
+		}),
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.OamFiles, oamFileFlag, oamFileFlagShort, []string{}, oamFileFlagDescription)
+	cmd.MarkFlagRequired(oamFileFlag)
+	cmd.Flags().StringVarP(&opts.Output, outputFlag, "", textOutput, validateOutputFlagDescription)
+	cmd.Flags().BoolVarP(&opts.Strict, strictFlag, "", os.Getenv(ciEnvVar) != "", validateStrictFlagDescription)
+
+	return cmd
+}
@@ -4,12 +4,15 @@ package cli
 
 import (
 	"fmt"
+	"io"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/aws/session"
 	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation"
 	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/types"
 	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/term/log"
 	termprogress "github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/term/progress"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/workload"
 	"github.com/spf13/cobra"
 )
 
@@ -27,21 +30,66 @@ type cfEnvironmentDeployer interface {
 
 // DeployEnvironmentOpts holds the configuration needed to deploy the oam-ecs environment.
 type DeployEnvironmentOpts struct {
-	DryRun bool
+	// Fields with matching flags
+	DryRun          bool
+	EC2Capacity     bool
+	EC2InstanceType string
+	EC2GPU          bool
+	EC2MaxSize      int
+	FileSystem      bool
+	LogRetention    int
+	LogKMSKeyARN    string
+	LogDeletion     string
+	Output          string
 
 	prog        progress
 	envDeployer cfEnvironmentDeployer
+	w           io.Writer
+	givenFlags  map[string]bool
 }
 
 // DeployEnvironmentOpts initiates the fields to provision an environment.
 func NewDeployEnvironmentOpts() *DeployEnvironmentOpts {
 	return &DeployEnvironmentOpts{
-		prog: termprogress.NewSpinner(),
+		Output:      types.TableFormat,
+		LogDeletion: defaultLogDeletion,
+		prog:        termprogress.NewSpinner(),
+		w:           log.OutputWriter,
 	}
 }
 
+// newEnvironmentInput sets only the settings whose flags were given, so that a redeployment keeps the others
+func (opts *DeployEnvironmentOpts) newEnvironmentInput() *types.EnvironmentInput {
+	input := &types.EnvironmentInput{}
+	if opts.givenFlags[ec2CapacityFlag] {
+		input.EC2Capacity = aws.Bool(opts.EC2Capacity)
+	}
+	if opts.givenFlags[ec2InstanceTypeFlag] {
+		input.EC2InstanceType = aws.String(opts.EC2InstanceType)
+	}
+	if opts.givenFlags[ec2GPUFlag] {
+		input.EC2GPU = aws.Bool(opts.EC2GPU)
+	}
+	if opts.givenFlags[ec2MaxSizeFlag] {
+		input.EC2MaxSize = aws.Int(opts.EC2MaxSize)
+	}
+	if opts.givenFlags[efsFlag] {
+		input.FileSystem = aws.Bool(opts.FileSystem)
+	}
+	if opts.givenFlags[logRetentionFlag] {
+		input.LogRetentionInDays = aws.Int(opts.LogRetention)
+	}
+	if opts.givenFlags[logKMSKeyFlag] {
+		input.LogKMSKeyARN = aws.String(opts.LogKMSKeyARN)
+	}
+	if opts.givenFlags[logDeletionFlag] {
+		input.LogDeletionPolicy = aws.String(opts.LogDeletion)
+	}
+	return input
+}
+
 func (opts *DeployEnvironmentOpts) dryRunEnvironment() error {
-	deployEnvInput := &types.EnvironmentInput{}
+	deployEnvInput := opts.newEnvironmentInput()
 
 	file, err := opts.envDeployer.DryRunEnvironment(deployEnvInput)
 	if err != nil {
@@ -54,7 +102,7 @@ func (opts *DeployEnvironmentOpts) dryRunEnvironment() error {
 }
 
 func (opts *DeployEnvironmentOpts) deployEnvironment() error {
-	deployEnvInput := &types.EnvironmentInput{}
+	deployEnvInput := opts.newEnvironmentInput()
 
 	opts.prog.Start(deployEnvStart)
 
@@ -66,13 +114,22 @@ func (opts *DeployEnvironmentOpts) deployEnvironment() error {
 
 	opts.prog.Stop(log.Ssuccessf(deployEnvSucceeded, env.StackName))
 
-	env.Display()
-
-	return nil
+	if opts.Output == types.TableFormat {
+		env.Display(opts.w)
+		return nil
+	}
+	return types.Marshal(opts.w, opts.Output, env)
 }
 
 // Execute deploys the environment CloudFormation stack
 func (opts *DeployEnvironmentOpts) Execute() error {
+	if err := types.ValidateFormat(opts.Output); err != nil {
+		return err
+	}
+	if err := workload.ValidateLogGroupSettings(opts.LogRetention, opts.LogKMSKeyARN, opts.LogDeletion); err != nil {
+		return err
+	}
+
 	if opts.DryRun {
 		return opts.dryRunEnvironment()
 	} else {
@@ -89,7 +146,16 @@ func BuildDeployEnvironmentCmd() *cobra.Command {
 		Long:  `Creates (or updates) the shared infrastructure, including a VPC and ECS cluster, for oam-ecs applications`,
 		Example: `
   Create the oam-ecs environment:
-	$ oam-ecs env deploy`,
+	$ oam-ecs env deploy
+
+  Create the oam-ecs environment with GPU container instances, for components that request GPUs:
+	$ oam-ecs env deploy --ec2-capacity --ec2-gpu --ec2-instance-type g4dn.xlarge
+
+  Create the oam-ecs environment with an EFS file system shared by components' persistent volumes:
+	$ oam-ecs env deploy --efs
+
+  Create the oam-ecs environment with log groups that keep logs for 30 days and outlive the applications:
+	$ oam-ecs env deploy --log-retention-days 30 --log-deletion-policy Retain`,
 		PreRunE: runCmdE(func(cmd *cobra.Command, args []string) error {
 			session, err := session.Default()
 			if err != nil {
@@ -99,11 +165,25 @@ func BuildDeployEnvironmentCmd() *cobra.Command {
 			return nil
 		}),
 		RunE: runCmdE(func(cmd *cobra.Command, args []string) error {
+			opts.givenFlags = make(map[string]bool)
+			for _, flag := range []string{ec2CapacityFlag, ec2InstanceTypeFlag, ec2GPUFlag, ec2MaxSizeFlag, efsFlag,
+				logRetentionFlag, logKMSKeyFlag, logDeletionFlag} {
+				opts.givenFlags[flag] = cmd.Flags().Changed(flag)
+			}
 			return opts.Execute()
 		}),
 	}
 
 	cmd.Flags().BoolVarP(&opts.DryRun, dryRunFlag, "", false, dryRunFlagDescription)
+	cmd.Flags().BoolVarP(&opts.EC2Capacity, ec2CapacityFlag, "", false, ec2CapacityFlagDescription)
+	cmd.Flags().StringVarP(&opts.EC2InstanceType, ec2InstanceTypeFlag, "", defaultEC2InstanceType, ec2InstanceTypeFlagDescription)
+	cmd.Flags().BoolVarP(&opts.EC2GPU, ec2GPUFlag, "", false, ec2GPUFlagDescription)
+	cmd.Flags().IntVarP(&opts.EC2MaxSize, ec2MaxSizeFlag, "", defaultEC2MaxSize, ec2MaxSizeFlagDescription)
+	cmd.Flags().BoolVarP(&opts.FileSystem, efsFlag, "", false, efsFlagDescription)
+	cmd.Flags().IntVarP(&opts.LogRetention, logRetentionFlag, "", 0, logRetentionFlagDescription)
+	cmd.Flags().StringVarP(&opts.LogKMSKeyARN, logKMSKeyFlag, "", "", logKMSKeyFlagDescription)
+	cmd.Flags().StringVarP(&opts.LogDeletion, logDeletionFlag, "", defaultLogDeletion, logDeletionFlagDescription)
+	cmd.Flags().StringVarP(&opts.Output, outputFlag, "", types.TableFormat, outputFlagDescription)
 
 	return cmd
 }
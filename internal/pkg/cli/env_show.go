@@ -3,6 +3,8 @@
 package cli
 
 import (
+	"io"
+
 	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/aws/session"
 	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation"
 	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/types"
@@ -22,17 +24,27 @@ type cfEnvironmentDescriber interface {
 }
 
 type ShowEnvironmentOpts struct {
+	// Fields with matching flags
+	Output string
+
 	prog         progress
 	envDescriber cfEnvironmentDescriber
+	w            io.Writer
 }
 
 func NewShowEnvironmentOpts() *ShowEnvironmentOpts {
 	return &ShowEnvironmentOpts{
-		prog: termprogress.NewSpinner(),
+		Output: types.TableFormat,
+		prog:   termprogress.NewSpinner(),
+		w:      log.OutputWriter,
 	}
 }
 
 func (opts *ShowEnvironmentOpts) Execute() error {
+	if err := types.ValidateFormat(opts.Output); err != nil {
+		return err
+	}
+
 	describeEnvInput := &types.EnvironmentInput{}
 
 	opts.prog.Start(showEnvStart)
@@ -45,9 +57,11 @@ func (opts *ShowEnvironmentOpts) Execute() error {
 
 	opts.prog.Stop(log.Ssuccessf(showEnvSucceeded, env.StackName))
 
-	env.Display()
-
-	return nil
+	if opts.Output == types.TableFormat {
+		env.Display(opts.w)
+		return nil
+	}
+	return types.Marshal(opts.w, opts.Output, env)
 }
 
 func BuildShowEnvironmentCmd() *cobra.Command {
@@ -58,7 +72,10 @@ func BuildShowEnvironmentCmd() *cobra.Command {
 		Long:  `Retrieves and displays the attributes of the oam-ecs default environment`,
 		Example: `
   Show the oam-ecs environment:
-	$ oam-ecs env show`,
+	$ oam-ecs env show
+
+  Show the oam-ecs environment as YAML:
+	$ oam-ecs env show --output yaml`,
 		PreRunE: runCmdE(func(cmd *cobra.Command, args []string) error {
 			session, err := session.Default()
 			if err != nil {
@@ -72,5 +89,7 @@ func BuildShowEnvironmentCmd() *cobra.Command {
 		}),
 	}
 
+	cmd.Flags().StringVarP(&opts.Output, outputFlag, "", types.TableFormat, outputFlagDescription)
+
 	return cmd
 }
@@ -4,8 +4,38 @@ package cli
 
 // Long flag names.
 const (
-	oamFileFlag = "filename"
-	dryRunFlag  = "dry-run"
+	oamFileFlag         = "filename"
+	dryRunFlag          = "dry-run"
+	ec2CapacityFlag     = "ec2-capacity"
+	ec2InstanceTypeFlag = "ec2-instance-type"
+	ec2GPUFlag          = "ec2-gpu"
+	ec2MaxSizeFlag      = "ec2-max-size"
+	efsFlag             = "efs"
+	strictFlag          = "strict"
+	warnFlag            = "warn"
+	outputFlag          = "output"
+	watchFlag           = "watch"
+	componentFlag       = "component"
+	containerFlag       = "container"
+	sinceFlag           = "since"
+	followFlag          = "follow"
+	filterFlag          = "filter"
+	taskFlag            = "task"
+	logRetentionFlag    = "log-retention-days"
+	logKMSKeyFlag       = "log-kms-key-arn"
+	logDeletionFlag     = "log-deletion-policy"
+	keepLogsFlag        = "keep-logs"
+	dashboardFlag       = "dashboard"
+)
+
+// Default flag values.
+const (
+	defaultEC2InstanceType = "m5.large"
+	defaultEC2MaxSize      = 4
+	defaultLogDeletion     = "Delete"
+
+	// ciEnvVar is set by most CI systems, and turns on strict validation by default
+	ciEnvVar = "CI"
 )
 
 // Short flag names.
@@ -16,7 +46,31 @@ const (
 
 // Descriptions for flags.
 const (
-	oamFileFlagDescription       = "Path to a file containing OAM component schematics or OAM application configuration. Multiple files can be provided either by repeating the flag for each file, or with a comma-delimited list of files."
-	dryRunFlagDescription        = "Write out an infrastructure template to a file instead of deploying the infrastructure"
-	appConfigFileFlagDescription = "Path to a file containing an OAM application configuration."
+	oamFileFlagDescription         = "Path to a file containing OAM component schematics or OAM application configuration. Multiple files can be provided either by repeating the flag for each file, or with a comma-delimited list of files."
+	dryRunFlagDescription          = "Write out an infrastructure template to a file instead of deploying the infrastructure"
+	appConfigFileFlagDescription   = "Path to a file containing an OAM application configuration."
+	ec2CapacityFlagDescription     = "Create an EC2 Auto Scaling group capacity provider, used by components that request GPUs or host volumes"
+	ec2InstanceTypeFlagDescription = "Instance type of the container instances in the EC2 capacity provider"
+	ec2GPUFlagDescription          = "Use the GPU variant of the ECS-optimized AMI for the container instances in the EC2 capacity provider"
+	ec2MaxSizeFlagDescription      = "Maximum number of container instances in the EC2 capacity provider"
+	efsFlagDescription             = "Create an EFS file system that components can share for their persistent volumes, using the efs trait"
+	strictFlagDescription          = "Fail if any attribute in the OAM files cannot be translated to ECS as written. Defaults to true if the CI environment variable is set"
+	warnFlagDescription            = "Only warn about attributes in the OAM files that cannot be translated to ECS as written, even in strict mode"
+	outputFlagDescription          = "Output format of the deployed attributes, table, json or yaml"
+	watchFlagDescription           = "Refresh the status every few seconds until interrupted"
+	componentFlagDescription       = "Instance name of the component in the application configuration"
+	containerFlagDescription       = "Only show the logs of this container of the component"
+	sinceFlagDescription           = "Only show the logs written since this long ago, such as 30m or 2h"
+	followFlagDescription          = "Keep polling for new logs until interrupted"
+	filterFlagDescription          = "Only show the logs that match this CloudWatch Logs filter pattern"
+	logsOutputFlagDescription      = "Format of the logs, text or json"
+	execContainerFlagDescription   = "Container of the component to run the command in. Prompts for a container if the task has several"
+	taskFlagDescription            = "ID of the task to run the command in. Prompts for a task if the component has several running tasks"
+	validateOutputFlagDescription  = "Format of the problems found, text or json"
+	logRetentionFlagDescription    = "Number of days the log groups of the component instances keep their logs, like 7, 30 or 365. Defaults to keeping them forever when the environment is created"
+	logKMSKeyFlagDescription       = "ARN of the KMS key that encrypts the log groups of the component instances. The key policy must allow CloudWatch Logs to use the key"
+	logDeletionFlagDescription     = "Deletion policy of the log groups of the component instances, Delete or Retain to keep the logs when the application is deleted"
+	keepLogsFlagDescription        = "Leave the log groups of the component instances in place, and list them"
+	dashboardFlagDescription       = "Also deploy a CloudWatch dashboard of the metrics and logs of the application's component instances"
+	validateStrictFlagDescription  = "Fail on attributes in the OAM files that cannot be translated to ECS as written, not only on errors. Defaults to true if the CI environment variable is set"
 )
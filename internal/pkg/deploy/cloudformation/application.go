@@ -0,0 +1,100 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudformation
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/stack"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/types"
+)
+
+// ListApplications finds the component instance stacks in the region by their oam-ecs-application tag,
+// and groups them by application and environment. Deleted stacks are not listed.
+func (cf CloudFormation) ListApplications() ([]*types.Application, error) {
+	applications := make(map[string]*types.Application)
+
+	err := cf.client.DescribeStacksPages(&cloudformation.DescribeStacksInput{}, func(page *cloudformation.DescribeStacksOutput, lastPage bool) bool {
+		for _, s := range page.Stacks {
+			tags := make(map[string]string)
+			for _, tag := range s.Tags {
+				tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+			}
+			appName, ok := tags[stack.AppTagKey]
+			if !ok {
+				continue
+			}
+			envName := tags[stack.EnvTagKey]
+
+			key := appName + "/" + envName
+			application, ok := applications[key]
+			if !ok {
+				application = &types.Application{
+					Name:        appName,
+					Environment: envName,
+				}
+				applications[key] = application
+			}
+
+			lastUpdated := aws.TimeValue(s.CreationTime)
+			if s.LastUpdatedTime != nil {
+				lastUpdated = *s.LastUpdatedTime
+			}
+			application.Components = append(application.Components, &types.ApplicationComponent{
+				InstanceName: tags[stack.ComponentTagKey],
+				StackName:    aws.StringValue(s.StackName),
+				StackStatus:  aws.StringValue(s.StackStatus),
+				LastUpdated:  lastUpdated,
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list stacks: %w", err)
+	}
+
+	result := make([]*types.Application, 0, len(applications))
+	for _, application := range applications {
+		sort.Slice(application.Components, func(i, j int) bool {
+			return application.Components[i].InstanceName < application.Components[j].InstanceName
+		})
+		application.Status, application.LastDeployed = aggregateStatus(application.Components)
+		result = append(result, application)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].Environment < result[j].Environment
+	})
+
+	return result, nil
+}
+
+// aggregateStatus returns the status of an application from the statuses of its component instance stacks:
+// in progress if any stack is in progress, failed if any stack failed, and complete otherwise.
+// Also returns the time that the most recently updated stack was updated.
+func aggregateStatus(components []*types.ApplicationComponent) (string, time.Time) {
+	status := types.ApplicationStatusComplete
+	var lastDeployed time.Time
+
+	for _, component := range components {
+		stackStatus := StackStatus(component.StackStatus)
+		switch {
+		case stackStatus.InProgress():
+			status = types.ApplicationStatusInProgress
+		case stackStatus.Failed() && status != types.ApplicationStatusInProgress:
+			status = types.ApplicationStatusFailed
+		}
+		if component.LastUpdated.After(lastDeployed) {
+			lastDeployed = component.LastUpdated
+		}
+	}
+
+	return status, lastDeployed
+}
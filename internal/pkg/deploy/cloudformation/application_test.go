@@ -0,0 +1,40 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package cloudformation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregateStatus(t *testing.T) {
+	earlier := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+
+	testCases := map[string]struct {
+		statuses []string
+		expected string
+	}{
+		"complete":    {[]string{"CREATE_COMPLETE", "UPDATE_COMPLETE"}, types.ApplicationStatusComplete},
+		"rolled back": {[]string{"UPDATE_COMPLETE", "UPDATE_ROLLBACK_COMPLETE"}, types.ApplicationStatusFailed},
+		"failed":      {[]string{"DELETE_FAILED", "CREATE_COMPLETE"}, types.ApplicationStatusFailed},
+		"in progress": {[]string{"UPDATE_ROLLBACK_COMPLETE", "UPDATE_IN_PROGRESS"}, types.ApplicationStatusInProgress},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			components := []*types.ApplicationComponent{
+				{StackStatus: tc.statuses[0], LastUpdated: later},
+				{StackStatus: tc.statuses[1], LastUpdated: earlier},
+			}
+
+			status, lastDeployed := aggregateStatus(components)
+
+			require.Equal(t, tc.expected, status)
+			require.Equal(t, later, lastDeployed)
+		})
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/stack"
 	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/types"
 )
@@ -72,34 +74,7 @@ func (cf CloudFormation) DryRunComponent(component *types.ComponentInput) (strin
 		return "", fmt.Errorf("template creation: %w", err)
 	}
 
-	templateFileDir := filepath.Join(".", templateFileDirectoryName)
-	if _, err := os.Stat(templateFileDir); os.IsNotExist(err) {
-		err = os.Mkdir(templateFileDir, os.ModePerm)
-		if err != nil {
-			return "", fmt.Errorf("could not create directory %s: %w", templateFileDir, err)
-		}
-	}
-
-	templateFileAbsDir, err := filepath.Abs(templateFileDir)
-	if err != nil {
-		return "", fmt.Errorf("could not get absolute path for directory %s: %w", templateFileDir, err)
-	}
-
-	templateFilePath := filepath.Join(templateFileAbsDir, stackConfig.StackName()+"-template.yaml")
-
-	f, err := os.Create(templateFilePath)
-	if err != nil {
-		return "", err
-	}
-	defer f.Close()
-
-	_, err = f.WriteString(template)
-	if err != nil {
-		return "", err
-	}
-	f.Sync()
-
-	return templateFilePath, nil
+	return writeTemplateFile(stackConfig.StackName(), template)
 }
 
 // DescribeComponent describes the existing CloudFormation stack for a component instance
@@ -133,3 +108,85 @@ func (cf CloudFormation) DeleteComponent(component *types.ComponentInput) (*type
 	}
 	return stackConfig.ToComponent(stack)
 }
+
+// DescribeComponentResources finds the ECS service and the load balancer target groups in the CloudFormation stack
+// for a component instance
+func (cf CloudFormation) DescribeComponentResources(component *types.ComponentInput) (*types.ComponentResources, error) {
+	stackConfig := stack.NewComponentStackConfig(component, cf.box)
+	out, err := cf.client.DescribeStackResources(&cloudformation.DescribeStackResourcesInput{
+		StackName: aws.String(stackConfig.StackName()),
+	})
+	if err != nil {
+		if stackDoesNotExist(err) {
+			return nil, &ErrStackNotFound{stackName: stackConfig.StackName()}
+		}
+		return nil, err
+	}
+
+	resources := &types.ComponentResources{
+		StackName:       stackConfig.StackName(),
+		TargetGroupARNs: []string{},
+	}
+	for _, resource := range out.StackResources {
+		switch aws.StringValue(resource.ResourceType) {
+		case "AWS::ECS::Service":
+			resources.ServiceARN = aws.StringValue(resource.PhysicalResourceId)
+		case "AWS::ElasticLoadBalancingV2::TargetGroup":
+			resources.TargetGroupARNs = append(resources.TargetGroupARNs, aws.StringValue(resource.PhysicalResourceId))
+		}
+	}
+	if resources.ServiceARN == "" {
+		return nil, fmt.Errorf("stack %s has no ECS service", stackConfig.StackName())
+	}
+
+	return resources, nil
+}
+
+// DeployedComponentTemplate returns the name of the CloudFormation stack for a component instance and the template
+// the stack was last deployed with. Returns an ErrStackNotFound if the stack does not exist.
+func (cf CloudFormation) DeployedComponentTemplate(component *types.ComponentInput) (string, string, error) {
+	stackConfig := stack.NewComponentStackConfig(component, cf.box)
+	out, err := cf.client.GetTemplate(&cloudformation.GetTemplateInput{
+		StackName:     aws.String(stackConfig.StackName()),
+		TemplateStage: aws.String(cloudformation.TemplateStageOriginal),
+	})
+	if err != nil {
+		if stackDoesNotExist(err) {
+			return stackConfig.StackName(), "", &ErrStackNotFound{stackName: stackConfig.StackName()}
+		}
+		return stackConfig.StackName(), "", fmt.Errorf("get template of stack %s: %w", stackConfig.StackName(), err)
+	}
+	return stackConfig.StackName(), aws.StringValue(out.TemplateBody), nil
+}
+
+// writeTemplateFile writes the template of a dry run to the oam-ecs-dry-run-results directory, and returns its path
+func writeTemplateFile(stackName string, template string) (string, error) {
+	templateFileDir := filepath.Join(".", templateFileDirectoryName)
+	if _, err := os.Stat(templateFileDir); os.IsNotExist(err) {
+		err = os.Mkdir(templateFileDir, os.ModePerm)
+		if err != nil {
+			return "", fmt.Errorf("could not create directory %s: %w", templateFileDir, err)
+		}
+	}
+
+	templateFileAbsDir, err := filepath.Abs(templateFileDir)
+	if err != nil {
+		return "", fmt.Errorf("could not get absolute path for directory %s: %w", templateFileDir, err)
+	}
+
+	templateFilePath := filepath.Join(templateFileAbsDir, stackName+"-template.yaml")
+
+	f, err := os.Create(templateFilePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(template)
+	if err != nil {
+		return "", err
+	}
+	f.Sync()
+
+	return templateFilePath, nil
+}
@@ -0,0 +1,117 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudformation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/stack"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/types"
+)
+
+// DeployDashboard creates the CloudFormation stack for the dashboard of an application by creating and executing
+// a change set, or updates the stack if it already exists.
+func (cf CloudFormation) DeployDashboard(dashboard *types.DashboardInput) (*types.Dashboard, error) {
+	dashboardConfig := stack.NewDashboardStackConfig(dashboard, cf.box)
+
+	// Try to create the stack
+	if _, err := cf.create(dashboardConfig); err != nil {
+		var existsErr *ErrStackAlreadyExists
+		if !errors.As(err, &existsErr) {
+			return nil, err
+		}
+
+		// Stack already exists, update the stack
+		deployStarted, err := cf.update(dashboardConfig)
+		if err != nil {
+			return nil, err
+		}
+		if !deployStarted {
+			// nothing to deploy
+			stack, err := cf.describe(dashboardConfig)
+			if err != nil {
+				return nil, err
+			}
+			return dashboardConfig.ToDashboard(stack)
+		}
+
+		// Wait for the stack to finish updating
+		stack, err := cf.waitForStackUpdate(dashboardConfig)
+		if err != nil {
+			return nil, err
+		}
+		return dashboardConfig.ToDashboard(stack)
+	}
+
+	// Wait for the stack to finish creation
+	stack, err := cf.waitForStackCreation(dashboardConfig)
+	if err != nil {
+		return nil, err
+	}
+	return dashboardConfig.ToDashboard(stack)
+}
+
+// DryRunDashboard writes the template of the dashboard of an application to disk
+func (cf CloudFormation) DryRunDashboard(dashboard *types.DashboardInput) (string, error) {
+	stackConfig := stack.NewDashboardStackConfig(dashboard, cf.box)
+	template, err := stackConfig.Template()
+	if err != nil {
+		return "", fmt.Errorf("template creation: %w", err)
+	}
+	return writeTemplateFile(stackConfig.StackName(), template)
+}
+
+// DescribeDashboard describes the existing CloudFormation stack for the dashboard of an application.
+// A stack without the dashboard tag is the stack of a component instance named dashboard, so it is not found.
+func (cf CloudFormation) DescribeDashboard(dashboard *types.DashboardInput) (*types.Dashboard, error) {
+	stackConfig := stack.NewDashboardStackConfig(dashboard, cf.box)
+	existing, err := cf.describe(stackConfig)
+	if err != nil {
+		return nil, err
+	}
+	if !hasTag(existing, stack.DashboardTagKey) {
+		return nil, &ErrStackNotFound{stackName: stackConfig.StackName()}
+	}
+	return stackConfig.ToDashboard(existing)
+}
+
+// DeleteDashboard deletes the CloudFormation stack for the dashboard of an application, if it exists.
+// The dashboard stack is named like the stack of a component instance named dashboard, so a stack
+// without the dashboard tag is left alone.
+func (cf CloudFormation) DeleteDashboard(dashboard *types.DashboardInput) (*types.Dashboard, error) {
+	stackConfig := stack.NewDashboardStackConfig(dashboard, cf.box)
+	existing, err := cf.describe(stackConfig)
+	if err != nil {
+		var notFoundErr *ErrStackNotFound
+		if errors.As(err, &notFoundErr) {
+			// Stack was not found, don't return an error, since it's deleted already
+			return &types.Dashboard{
+				StackName: stackConfig.StackName(),
+			}, nil
+		}
+		return nil, err
+	}
+	if !hasTag(existing, stack.DashboardTagKey) {
+		return &types.Dashboard{
+			StackName: stackConfig.StackName(),
+		}, nil
+	}
+	if err := cf.delete(*existing.StackId); err != nil {
+		return nil, err
+	}
+	return stackConfig.ToDashboard(existing)
+}
+
+// hasTag checks whether a stack is tagged with the given key
+func hasTag(existing *cloudformation.Stack, key string) bool {
+	for _, tag := range existing.Tags {
+		if aws.StringValue(tag.Key) == key {
+			return true
+		}
+	}
+	return false
+}
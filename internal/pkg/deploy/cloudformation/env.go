@@ -7,9 +7,8 @@ package cloudformation
 import (
 	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/stack"
 	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/types"
 )
@@ -27,7 +26,16 @@ func (cf CloudFormation) DeployEnvironment(env *types.EnvironmentInput) (*types.
 	if _, err := cf.create(envConfig); err != nil {
 		var existsErr *ErrStackAlreadyExists
 		if errors.As(err, &existsErr) {
-			// Stack already exists, update the stack
+			// Stack already exists, update the stack and keep the settings that were not given
+			existing, err := cf.describe(envConfig)
+			if err != nil {
+				return nil, err
+			}
+			env.ExistingParameters = make(map[string]bool)
+			for _, param := range existing.Parameters {
+				env.ExistingParameters[aws.StringValue(param.ParameterKey)] = true
+			}
+
 			deployStarted, err := cf.update(envConfig)
 			if err != nil {
 				return nil, err
@@ -68,34 +76,7 @@ func (cf CloudFormation) DryRunEnvironment(env *types.EnvironmentInput) (string,
 		return "", fmt.Errorf("template creation: %w", err)
 	}
 
-	templateFileDir := filepath.Join(".", templateFileDirectoryName)
-	if _, err := os.Stat(templateFileDir); os.IsNotExist(err) {
-		err = os.Mkdir(templateFileDir, os.ModePerm)
-		if err != nil {
-			return "", fmt.Errorf("could not create directory %s: %w", templateFileDir, err)
-		}
-	}
-
-	templateFileAbsDir, err := filepath.Abs(templateFileDir)
-	if err != nil {
-		return "", fmt.Errorf("could not get absolute path for directory %s: %w", templateFileDir, err)
-	}
-
-	templateFilePath := filepath.Join(templateFileAbsDir, stackConfig.StackName()+"-template.yaml")
-
-	f, err := os.Create(templateFilePath)
-	if err != nil {
-		return "", err
-	}
-	defer f.Close()
-
-	_, err = f.WriteString(template)
-	if err != nil {
-		return "", err
-	}
-	f.Sync()
-
-	return templateFilePath, nil
+	return writeTemplateFile(stackConfig.StackName(), template)
 }
 
 // DescribeEnvironment describes the existing CloudFormation stack for an environment
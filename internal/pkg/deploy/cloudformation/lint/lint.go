@@ -0,0 +1,346 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package lint checks rendered CloudFormation templates without calling AWS.
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Finding describes a problem in a CloudFormation template
+type Finding struct {
+	// Path is the dotted path of the offending node in the template, e.g. Resources.Service.Properties.Cluster
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (finding *Finding) String() string {
+	if finding.Path == "" {
+		return finding.Message
+	}
+	return fmt.Sprintf("%s: %s", finding.Path, finding.Message)
+}
+
+var subVariablePattern = regexp.MustCompile(`\$\{([^!}][^}]*)\}`)
+
+// linter holds the state of a template check
+type linter struct {
+	// names that !Ref and !Sub can refer to: parameters and resources
+	refs map[string]bool
+	// logical IDs of the template's resources
+	resources map[string]bool
+	findings  []*Finding
+}
+
+func (l *linter) report(path string, format string, args ...interface{}) {
+	l.findings = append(l.findings, &Finding{
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// Template checks that a rendered template is valid YAML, only uses known sections and intrinsic functions,
+// that references point to existing parameters and resources, and that the properties of the resources
+// have the expected names and value types. Returns the findings in template order, or an empty list.
+func Template(body string) []*Finding {
+	l := &linter{
+		refs:      make(map[string]bool),
+		resources: make(map[string]bool),
+		findings:  []*Finding{},
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(body), &document); err != nil {
+		l.report("", "Template is not valid YAML: %v", err)
+		return l.findings
+	}
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		l.report("", "Template must be a YAML map")
+		return l.findings
+	}
+	root := document.Content[0]
+
+	for _, section := range []string{"Parameters", "Resources"} {
+		if node := lookup(root, section); node != nil && node.Kind == yaml.MappingNode {
+			for i := 0; i < len(node.Content); i += 2 {
+				name := node.Content[i].Value
+				l.refs[name] = true
+				if section == "Resources" {
+					l.resources[name] = true
+				}
+			}
+		}
+	}
+
+	for i := 0; i < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		if !templateSections[key.Value] {
+			l.report(key.Value, "Unknown template section %s", key.Value)
+			continue
+		}
+		switch key.Value {
+		case "Resources":
+			l.checkResources(value)
+		case "Outputs":
+			l.checkOutputs(value)
+		}
+		l.checkIntrinsics(key.Value, value)
+	}
+
+	return l.findings
+}
+
+// checkResources checks the resource attributes and the properties of every resource
+func (l *linter) checkResources(resources *yaml.Node) {
+	if resources.Kind != yaml.MappingNode || len(resources.Content) == 0 {
+		l.report("Resources", "Resources must be a map with at least one resource")
+		return
+	}
+
+	for i := 0; i < len(resources.Content); i += 2 {
+		name, resource := resources.Content[i].Value, resources.Content[i+1]
+		path := "Resources." + name
+		if resource.Kind != yaml.MappingNode {
+			l.report(path, "Resource must be a map")
+			continue
+		}
+
+		for j := 0; j < len(resource.Content); j += 2 {
+			attribute := resource.Content[j].Value
+			if !resourceAttributes[attribute] {
+				l.report(path+"."+attribute, "Unknown resource attribute %s", attribute)
+			}
+		}
+
+		if dependsOn := lookup(resource, "DependsOn"); dependsOn != nil {
+			targets := []*yaml.Node{dependsOn}
+			if dependsOn.Kind == yaml.SequenceNode {
+				targets = dependsOn.Content
+			}
+			for _, target := range targets {
+				if target.Kind != yaml.ScalarNode || !l.resources[target.Value] {
+					l.report(path+".DependsOn", "DependsOn refers to %s, which is not a resource of the template", target.Value)
+				}
+			}
+		}
+
+		resourceType := lookup(resource, "Type")
+		if resourceType == nil || resourceType.Kind != yaml.ScalarNode || resourceType.Value == "" {
+			l.report(path, "Resource has no type")
+			continue
+		}
+		schema, ok := resourceSchemas[resourceType.Value]
+		if !ok {
+			l.report(path+".Type", "Resource type %s is not emitted by the oam-ecs templates", resourceType.Value)
+			continue
+		}
+
+		properties := lookup(resource, "Properties")
+		if properties == nil {
+			properties = &yaml.Node{Kind: yaml.MappingNode}
+		}
+		if properties.Kind != yaml.MappingNode {
+			l.report(path+".Properties", "Properties must be a map")
+			continue
+		}
+		l.checkProperties(path+".Properties", properties, schema.properties, schema.required)
+
+		if resourceType.Value == "AWS::ECS::TaskDefinition" {
+			if containers := lookup(properties, "ContainerDefinitions"); containers != nil && containers.Kind == yaml.SequenceNode {
+				for j, container := range containers.Content {
+					containerPath := fmt.Sprintf("%s.ContainerDefinitions[%d]", path+".Properties", j)
+					if container.Kind != yaml.MappingNode {
+						l.report(containerPath, "Container definition must be a map")
+						continue
+					}
+					l.checkProperties(containerPath, container, containerDefinitionProperties, []string{"Name", "Image"})
+				}
+			}
+		}
+	}
+}
+
+// checkProperties checks property names and value types against a schema
+func (l *linter) checkProperties(path string, properties *yaml.Node, schema map[string]kind, required []string) {
+	for _, name := range required {
+		if lookup(properties, name) == nil {
+			l.report(path, "Required property %s is missing", name)
+		}
+	}
+
+	for i := 0; i < len(properties.Content); i += 2 {
+		name, value := properties.Content[i].Value, properties.Content[i+1]
+		propertyPath := path + "." + name
+		expected, ok := schema[name]
+		if !ok {
+			l.report(propertyPath, "Unknown property %s", name)
+			continue
+		}
+		if isIntrinsic(value) {
+			continue
+		}
+		if !hasKind(value, expected) {
+			l.report(propertyPath, "Property %s must be %s", name, expected)
+		}
+	}
+}
+
+// checkOutputs checks that every output has a value
+func (l *linter) checkOutputs(outputs *yaml.Node) {
+	if outputs.Kind != yaml.MappingNode {
+		l.report("Outputs", "Outputs must be a map")
+		return
+	}
+	for i := 0; i < len(outputs.Content); i += 2 {
+		name, output := outputs.Content[i].Value, outputs.Content[i+1]
+		if output.Kind != yaml.MappingNode || lookup(output, "Value") == nil {
+			l.report("Outputs."+name, "Output has no value")
+		}
+	}
+}
+
+// checkIntrinsics walks a node and checks the intrinsic functions used in it, in both the short and the long form
+func (l *linter) checkIntrinsics(path string, node *yaml.Node) {
+	if strings.HasPrefix(node.Tag, "!") && !strings.HasPrefix(node.Tag, "!!") {
+		name := strings.TrimPrefix(node.Tag, "!")
+		if name != "Ref" && name != "Condition" {
+			name = "Fn::" + name
+		}
+		l.checkFunction(path, name, node)
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		if len(node.Content) == 2 && isFunctionKey(node.Content[0].Value) {
+			name := node.Content[0].Value
+			if !intrinsicFunctions[name] {
+				l.report(path, "Unknown intrinsic function %s", name)
+			} else {
+				l.checkFunction(path, name, node.Content[1])
+			}
+			l.checkIntrinsics(path+"."+name, node.Content[1])
+			return
+		}
+		for i := 0; i < len(node.Content); i += 2 {
+			l.checkIntrinsics(path+"."+node.Content[i].Value, node.Content[i+1])
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			l.checkIntrinsics(fmt.Sprintf("%s[%d]", path, i), item)
+		}
+	}
+}
+
+// checkFunction checks the arguments of the functions that refer to parameters and resources
+func (l *linter) checkFunction(path string, name string, args *yaml.Node) {
+	if !intrinsicFunctions[name] {
+		l.report(path, "Unknown intrinsic function %s", strings.TrimPrefix(name, "Fn::"))
+		return
+	}
+
+	switch name {
+	case "Ref":
+		if args.Kind != yaml.ScalarNode {
+			l.report(path, "Ref takes the name of a parameter or resource")
+		} else if !l.refs[args.Value] && !pseudoParameters[args.Value] {
+			l.report(path, "Ref refers to %s, which is not a parameter or resource of the template", args.Value)
+		}
+	case "Fn::GetAtt":
+		var resource string
+		switch {
+		case args.Kind == yaml.ScalarNode && strings.Contains(args.Value, "."):
+			resource = strings.SplitN(args.Value, ".", 2)[0]
+		case args.Kind == yaml.SequenceNode && len(args.Content) == 2:
+			resource = args.Content[0].Value
+		default:
+			l.report(path, "GetAtt takes a resource name and an attribute name")
+			return
+		}
+		if !l.resources[resource] {
+			l.report(path, "GetAtt refers to %s, which is not a resource of the template", resource)
+		}
+	case "Fn::Sub":
+		format := args
+		variables := map[string]bool{}
+		if args.Kind == yaml.SequenceNode {
+			if len(args.Content) != 2 || args.Content[1].Kind != yaml.MappingNode {
+				l.report(path, "Sub takes a string or a list of a string and a map of variables")
+				return
+			}
+			format = args.Content[0]
+			for i := 0; i < len(args.Content[1].Content); i += 2 {
+				variables[args.Content[1].Content[i].Value] = true
+			}
+		}
+		if format.Kind != yaml.ScalarNode {
+			l.report(path, "Sub takes a string or a list of a string and a map of variables")
+			return
+		}
+		for _, match := range subVariablePattern.FindAllStringSubmatch(format.Value, -1) {
+			variable := match[1]
+			switch {
+			case variables[variable], pseudoParameters[variable], l.refs[variable]:
+			case strings.Contains(variable, ".") && l.resources[strings.SplitN(variable, ".", 2)[0]]:
+			default:
+				l.report(path, "Sub refers to ${%s}, which is not a parameter, resource or variable", variable)
+			}
+		}
+	}
+}
+
+// isFunctionKey returns whether a map key names an intrinsic function in the long form
+func isFunctionKey(key string) bool {
+	return key == "Ref" || strings.HasPrefix(key, "Fn::")
+}
+
+// isIntrinsic returns whether the value of a node is computed by an intrinsic function
+func isIntrinsic(node *yaml.Node) bool {
+	if strings.HasPrefix(node.Tag, "!") && !strings.HasPrefix(node.Tag, "!!") {
+		return true
+	}
+	return node.Kind == yaml.MappingNode && len(node.Content) == 2 && isFunctionKey(node.Content[0].Value)
+}
+
+// hasKind returns whether a literal node has the expected value type. Like CloudFormation, it accepts
+// numbers and booleans for strings, and strings that parse as numbers and booleans for those.
+func hasKind(node *yaml.Node, expected kind) bool {
+	switch expected {
+	case kindList:
+		return node.Kind == yaml.SequenceNode
+	case kindMap:
+		return node.Kind == yaml.MappingNode
+	}
+
+	if node.Kind != yaml.ScalarNode || node.Tag == "!!null" {
+		return false
+	}
+	switch expected {
+	case kindNumber:
+		_, err := strconv.ParseFloat(node.Value, 64)
+		return err == nil
+	case kindBoolean:
+		_, err := strconv.ParseBool(node.Value)
+		return err == nil
+	default:
+		return true
+	}
+}
+
+// lookup returns the value of a key in a map node, or nil
+func lookup(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
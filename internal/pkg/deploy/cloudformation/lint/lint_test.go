@@ -0,0 +1,87 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func messages(findings []*Finding) []string {
+	result := []string{}
+	for _, finding := range findings {
+		result = append(result, finding.String())
+	}
+	return result
+}
+
+func TestTemplateValid(t *testing.T) {
+	body := `
+Parameters:
+  Cluster:
+    Type: String
+Resources:
+  LogGroup:
+    Type: AWS::Logs::LogGroup
+    Properties:
+      LogGroupName: !Sub '/ecs/${AWS::StackName}'
+      RetentionInDays: 30
+  Service:
+    Type: AWS::ECS::Service
+    DependsOn: LogGroup
+    Properties:
+      Cluster: !Ref Cluster
+      DesiredCount: '2'
+      EnableExecuteCommand: false
+Outputs:
+  LogGroupArn:
+    Value: !GetAtt LogGroup.Arn
+`
+	require.Empty(t, Template(body))
+}
+
+func TestTemplateInvalidYAML(t *testing.T) {
+	findings := Template("Resources:\n  - a\n b: c")
+	require.Len(t, findings, 1)
+	require.Contains(t, findings[0].Message, "Template is not valid YAML")
+}
+
+func TestTemplateFindings(t *testing.T) {
+	body := `
+Resoures: {}
+Resources:
+  LogGroup:
+    Type: AWS::Logs::LogGroup
+    DependsOn: Missing
+    Properties:
+      LogGroupName: !Sub '${Unknown}-${!Literal}'
+      RetentionInDays: thirty
+      Retention: 30
+  Service:
+    Type: AWS::ECS::Service
+    Properties:
+      Cluster: !Ref Cluster
+      LoadBalancers:
+        Fn::Joined: []
+      NetworkConfiguration:
+        Subnets: !Split [',', !Base65 'a,b']
+  Queue:
+    Type: AWS::SQS::Queue
+Outputs:
+  Arn:
+    Description: no value
+`
+	require.Equal(t, []string{
+		"Resoures: Unknown template section Resoures",
+		"Resources.LogGroup.DependsOn: DependsOn refers to Missing, which is not a resource of the template",
+		"Resources.LogGroup.Properties.RetentionInDays: Property RetentionInDays must be a number",
+		"Resources.LogGroup.Properties.Retention: Unknown property Retention",
+		"Resources.Queue.Type: Resource type AWS::SQS::Queue is not emitted by the oam-ecs templates",
+		"Resources.LogGroup.Properties.LogGroupName: Sub refers to ${Unknown}, which is not a parameter, resource or variable",
+		"Resources.Service.Properties.Cluster: Ref refers to Cluster, which is not a parameter or resource of the template",
+		"Resources.Service.Properties.LoadBalancers: Unknown intrinsic function Fn::Joined",
+		"Resources.Service.Properties.NetworkConfiguration.Subnets[1]: Unknown intrinsic function Base65",
+		"Outputs.Arn: Output has no value",
+	}, messages(Template(body)))
+}
@@ -0,0 +1,342 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package lint
+
+// kind is the expected YAML value type of a resource property
+type kind int
+
+const (
+	kindString kind = iota
+	kindNumber
+	kindBoolean
+	kindList
+	kindMap
+)
+
+func (k kind) String() string {
+	switch k {
+	case kindNumber:
+		return "a number"
+	case kindBoolean:
+		return "a boolean"
+	case kindList:
+		return "a list"
+	case kindMap:
+		return "a map"
+	default:
+		return "a string"
+	}
+}
+
+// resourceSchema describes the properties of a resource type emitted by the oam-ecs templates
+type resourceSchema struct {
+	properties map[string]kind
+	required   []string
+}
+
+// resourceSchemas lists the resource types that the component templates emit. A template that uses
+// any other resource type is reported, so that this list is kept up to date with the templates.
+var resourceSchemas = map[string]resourceSchema{
+	"AWS::Logs::LogGroup": {
+		properties: map[string]kind{
+			"LogGroupName":    kindString,
+			"RetentionInDays": kindNumber,
+			"KmsKeyId":        kindString,
+			"Tags":            kindList,
+		},
+	},
+	"AWS::ECS::TaskDefinition": {
+		properties: map[string]kind{
+			"Family":                  kindString,
+			"NetworkMode":             kindString,
+			"RequiresCompatibilities": kindList,
+			"Cpu":                     kindString,
+			"Memory":                  kindString,
+			"EphemeralStorage":        kindMap,
+			"ExecutionRoleArn":        kindString,
+			"TaskRoleArn":             kindString,
+			"ContainerDefinitions":    kindList,
+			"Volumes":                 kindList,
+			"PlacementConstraints":    kindList,
+			"ProxyConfiguration":      kindMap,
+			"RuntimePlatform":         kindMap,
+			"Tags":                    kindList,
+		},
+	},
+	"AWS::ECS::Service": {
+		properties: map[string]kind{
+			"Cluster":                       kindString,
+			"TaskDefinition":                kindString,
+			"DeploymentConfiguration":       kindMap,
+			"DesiredCount":                  kindNumber,
+			"LaunchType":                    kindString,
+			"CapacityProviderStrategy":      kindList,
+			"NetworkConfiguration":          kindMap,
+			"LoadBalancers":                 kindList,
+			"HealthCheckGracePeriodSeconds": kindNumber,
+			"ServiceName":                   kindString,
+			"ServiceRegistries":             kindList,
+			"EnableExecuteCommand":          kindBoolean,
+			"EnableECSManagedTags":          kindBoolean,
+			"PropagateTags":                 kindString,
+			"PlatformVersion":               kindString,
+			"Tags":                          kindList,
+		},
+		required: []string{"Cluster"},
+	},
+	"AWS::EFS::FileSystem": {
+		properties: map[string]kind{
+			"Encrypted":         kindBoolean,
+			"FileSystemTags":    kindList,
+			"PerformanceMode":   kindString,
+			"ThroughputMode":    kindString,
+			"KmsKeyId":          kindString,
+			"LifecyclePolicies": kindList,
+			"BackupPolicy":      kindMap,
+		},
+	},
+	"AWS::EFS::MountTarget": {
+		properties: map[string]kind{
+			"FileSystemId":   kindString,
+			"SubnetId":       kindString,
+			"SecurityGroups": kindList,
+			"IpAddress":      kindString,
+		},
+		required: []string{"FileSystemId", "SubnetId", "SecurityGroups"},
+	},
+	"AWS::EFS::AccessPoint": {
+		properties: map[string]kind{
+			"FileSystemId":    kindString,
+			"PosixUser":       kindMap,
+			"RootDirectory":   kindMap,
+			"AccessPointTags": kindList,
+		},
+		required: []string{"FileSystemId"},
+	},
+	"AWS::EC2::SecurityGroup": {
+		properties: map[string]kind{
+			"GroupDescription":     kindString,
+			"GroupName":            kindString,
+			"VpcId":                kindString,
+			"SecurityGroupIngress": kindList,
+			"SecurityGroupEgress":  kindList,
+			"Tags":                 kindList,
+		},
+		required: []string{"GroupDescription"},
+	},
+	"AWS::EC2::SecurityGroupIngress": {
+		properties: map[string]kind{
+			"GroupId":               kindString,
+			"IpProtocol":            kindString,
+			"FromPort":              kindNumber,
+			"ToPort":                kindNumber,
+			"CidrIp":                kindString,
+			"CidrIpv6":              kindString,
+			"SourcePrefixListId":    kindString,
+			"SourceSecurityGroupId": kindString,
+			"Description":           kindString,
+		},
+		required: []string{"IpProtocol"},
+	},
+	"AWS::ServiceDiscovery::Service": {
+		properties: map[string]kind{
+			"Name":                    kindString,
+			"Description":             kindString,
+			"NamespaceId":             kindString,
+			"DnsConfig":               kindMap,
+			"HealthCheckConfig":       kindMap,
+			"HealthCheckCustomConfig": kindMap,
+			"Tags":                    kindList,
+		},
+	},
+	"AWS::SSM::Parameter": {
+		properties: map[string]kind{
+			"Name":        kindString,
+			"Description": kindString,
+			"Type":        kindString,
+			"Tier":        kindString,
+			"Value":       kindString,
+			"Tags":        kindMap,
+		},
+		required: []string{"Type", "Value"},
+	},
+	"AWS::IAM::Role": {
+		properties: map[string]kind{
+			"AssumeRolePolicyDocument": kindMap,
+			"Description":              kindString,
+			"ManagedPolicyArns":        kindList,
+			"MaxSessionDuration":       kindNumber,
+			"Path":                     kindString,
+			"PermissionsBoundary":      kindString,
+			"Policies":                 kindList,
+			"RoleName":                 kindString,
+			"Tags":                     kindList,
+		},
+		required: []string{"AssumeRolePolicyDocument"},
+	},
+	"AWS::ElasticLoadBalancingV2::LoadBalancer": {
+		properties: map[string]kind{
+			"Name":                   kindString,
+			"Scheme":                 kindString,
+			"Type":                   kindString,
+			"Subnets":                kindList,
+			"SubnetMappings":         kindList,
+			"SecurityGroups":         kindList,
+			"LoadBalancerAttributes": kindList,
+			"IpAddressType":          kindString,
+			"Tags":                   kindList,
+		},
+	},
+	"AWS::ElasticLoadBalancingV2::Listener": {
+		properties: map[string]kind{
+			"LoadBalancerArn": kindString,
+			"Port":            kindNumber,
+			"Protocol":        kindString,
+			"DefaultActions":  kindList,
+			"Certificates":    kindList,
+			"SslPolicy":       kindString,
+		},
+		required: []string{"LoadBalancerArn", "DefaultActions"},
+	},
+	"AWS::ElasticLoadBalancingV2::TargetGroup": {
+		properties: map[string]kind{
+			"Name":                       kindString,
+			"Protocol":                   kindString,
+			"TargetType":                 kindString,
+			"Port":                       kindNumber,
+			"VpcId":                      kindString,
+			"TargetGroupAttributes":      kindList,
+			"HealthCheckEnabled":         kindBoolean,
+			"HealthCheckProtocol":        kindString,
+			"HealthCheckPath":            kindString,
+			"HealthCheckPort":            kindString,
+			"HealthCheckTimeoutSeconds":  kindNumber,
+			"HealthCheckIntervalSeconds": kindNumber,
+			"HealthyThresholdCount":      kindNumber,
+			"UnhealthyThresholdCount":    kindNumber,
+			"Matcher":                    kindMap,
+			"Tags":                       kindList,
+		},
+	},
+	"AWS::CloudWatch::Alarm": {
+		properties: map[string]kind{
+			"AlarmName":          kindString,
+			"AlarmDescription":   kindString,
+			"Namespace":          kindString,
+			"MetricName":         kindString,
+			"Dimensions":         kindList,
+			"Statistic":          kindString,
+			"Period":             kindNumber,
+			"EvaluationPeriods":  kindNumber,
+			"DatapointsToAlarm":  kindNumber,
+			"Threshold":          kindNumber,
+			"ComparisonOperator": kindString,
+			"TreatMissingData":   kindString,
+			"AlarmActions":       kindList,
+			"OKActions":          kindList,
+		},
+		required: []string{"ComparisonOperator", "EvaluationPeriods"},
+	},
+	"AWS::SNS::Topic": {
+		properties: map[string]kind{
+			"TopicName":      kindString,
+			"DisplayName":    kindString,
+			"KmsMasterKeyId": kindString,
+			"Subscription":   kindList,
+			"Tags":           kindList,
+		},
+	},
+}
+
+// containerDefinitionProperties describes the properties of the items of AWS::ECS::TaskDefinition ContainerDefinitions
+var containerDefinitionProperties = map[string]kind{
+	"Name":                   kindString,
+	"Image":                  kindString,
+	"Essential":              kindBoolean,
+	"Cpu":                    kindNumber,
+	"Memory":                 kindNumber,
+	"MemoryReservation":      kindNumber,
+	"PortMappings":           kindList,
+	"Environment":            kindList,
+	"Secrets":                kindList,
+	"MountPoints":            kindList,
+	"VolumesFrom":            kindList,
+	"EntryPoint":             kindList,
+	"Command":                kindList,
+	"HealthCheck":            kindMap,
+	"DependsOn":              kindList,
+	"LogConfiguration":       kindMap,
+	"FirelensConfiguration":  kindMap,
+	"RepositoryCredentials":  kindMap,
+	"ResourceRequirements":   kindList,
+	"WorkingDirectory":       kindString,
+	"User":                   kindString,
+	"Ulimits":                kindList,
+	"LinuxParameters":        kindMap,
+	"ReadonlyRootFilesystem": kindBoolean,
+	"StartTimeout":           kindNumber,
+	"StopTimeout":            kindNumber,
+	"DockerLabels":           kindMap,
+}
+
+// resourceAttributes lists the attributes allowed next to the type and properties of a resource
+var resourceAttributes = map[string]bool{
+	"Type":                true,
+	"Properties":          true,
+	"DependsOn":           true,
+	"Condition":           true,
+	"DeletionPolicy":      true,
+	"UpdateReplacePolicy": true,
+	"Metadata":            true,
+	"CreationPolicy":      true,
+	"UpdatePolicy":        true,
+}
+
+// templateSections lists the top-level sections of a CloudFormation template
+var templateSections = map[string]bool{
+	"AWSTemplateFormatVersion": true,
+	"Description":              true,
+	"Metadata":                 true,
+	"Parameters":               true,
+	"Mappings":                 true,
+	"Conditions":               true,
+	"Transform":                true,
+	"Resources":                true,
+	"Outputs":                  true,
+}
+
+// intrinsicFunctions lists the intrinsic functions by their full names, as used in the long form "Fn::Name: ..."
+// and, without the "Fn::" prefix, in the short form "!Name ..."
+var intrinsicFunctions = map[string]bool{
+	"Ref":             true,
+	"Condition":       true,
+	"Fn::Base64":      true,
+	"Fn::Cidr":        true,
+	"Fn::FindInMap":   true,
+	"Fn::GetAtt":      true,
+	"Fn::GetAZs":      true,
+	"Fn::ImportValue": true,
+	"Fn::Join":        true,
+	"Fn::Select":      true,
+	"Fn::Split":       true,
+	"Fn::Sub":         true,
+	"Fn::Transform":   true,
+	"Fn::And":         true,
+	"Fn::Equals":      true,
+	"Fn::If":          true,
+	"Fn::Not":         true,
+	"Fn::Or":          true,
+}
+
+// pseudoParameters lists the parameters that are predefined by CloudFormation
+var pseudoParameters = map[string]bool{
+	"AWS::AccountId":        true,
+	"AWS::NotificationARNs": true,
+	"AWS::NoValue":          true,
+	"AWS::Partition":        true,
+	"AWS::Region":           true,
+	"AWS::StackId":          true,
+	"AWS::StackName":        true,
+	"AWS::URLSuffix":        true,
+}
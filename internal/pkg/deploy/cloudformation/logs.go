@@ -0,0 +1,136 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package cloudformation
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/stack"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/types"
+	"gopkg.in/yaml.v3"
+)
+
+// keptLogsTagKey tags the log groups whose deletion policy was changed to Retain before their stack was deleted
+const keptLogsTagKey = "oam-ecs-kept-logs"
+
+// templateStackConfig deploys a stack with a given template, instead of rendering it
+type templateStackConfig struct {
+	stackConfiguration
+	template string
+}
+
+func (c *templateStackConfig) Template() (string, error) {
+	return c.template, nil
+}
+
+// RetainComponentLogGroup updates the CloudFormation stack for a component instance so that deleting the stack leaves
+// its log group in place, and returns the name of the log group. Returns an empty name if the stack does not exist.
+func (cf CloudFormation) RetainComponentLogGroup(component *types.ComponentInput) (string, error) {
+	stackConfig := stack.NewComponentStackConfig(component, cf.box)
+	_, deployed, err := cf.DeployedComponentTemplate(component)
+	if err != nil {
+		var notFoundErr *ErrStackNotFound
+		if errors.As(err, &notFoundErr) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	retained, changed, err := retainLogGroup(deployed)
+	if err != nil {
+		return "", fmt.Errorf("stack %s: %w", stackConfig.StackName(), err)
+	}
+	if !changed {
+		return component.LogGroupName(), nil
+	}
+
+	retainedConfig := &templateStackConfig{stackConfiguration: stackConfig, template: retained}
+	deployStarted, err := cf.update(retainedConfig)
+	if err != nil {
+		return "", err
+	}
+	if deployStarted {
+		if _, err := cf.waitForStackUpdate(retainedConfig); err != nil {
+			return "", err
+		}
+	}
+	return component.LogGroupName(), nil
+}
+
+// retainLogGroup sets the deletion policy of the log group in a component instance template to Retain. A change of
+// the deletion policy alone is not a change of the resource, so the log group is tagged too for the stack update to
+// apply it. The template is edited as a YAML document, which keeps the intrinsic function tags, and re-encoded.
+// Returns false if the log group is already retained.
+func retainLogGroup(template string) (string, bool, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(template), &document); err != nil {
+		return "", false, fmt.Errorf("parse template: %w", err)
+	}
+	if len(document.Content) == 0 {
+		return "", false, errors.New("template has no log group")
+	}
+	logGroup := lookup(lookup(document.Content[0], "Resources"), "LogGroup")
+	if logGroup == nil || logGroup.Kind != yaml.MappingNode {
+		return "", false, errors.New("template has no log group")
+	}
+	if policy := lookup(logGroup, "DeletionPolicy"); policy != nil && policy.Value == "Retain" {
+		return template, false, nil
+	}
+
+	setScalar(logGroup, "DeletionPolicy", "Retain")
+	setScalar(logGroup, "UpdateReplacePolicy", "Retain")
+	properties := lookup(logGroup, "Properties")
+	if properties == nil {
+		properties = &yaml.Node{Kind: yaml.MappingNode}
+		logGroup.Content = append(logGroup.Content, scalar("Properties"), properties)
+	}
+	tags := lookup(properties, "Tags")
+	if tags == nil {
+		tags = &yaml.Node{Kind: yaml.SequenceNode}
+		properties.Content = append(properties.Content, scalar("Tags"), tags)
+	}
+	tag := &yaml.Node{Kind: yaml.MappingNode}
+	setScalar(tag, "Key", keptLogsTagKey)
+	setScalar(tag, "Value", "true")
+	lookup(tag, "Value").Style = yaml.SingleQuotedStyle
+	tags.Content = append(tags.Content, tag)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return "", false, fmt.Errorf("encode template: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return "", false, fmt.Errorf("encode template: %w", err)
+	}
+	return buf.String(), true, nil
+}
+
+// lookup returns the value of a key in a YAML map, or nil if the node is not a map or has no such key
+func lookup(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setScalar sets the value of a key in a YAML map to a string, adding the key if it is missing
+func setScalar(node *yaml.Node, key string, value string) {
+	if existing := lookup(node, key); existing != nil {
+		*existing = *scalar(value)
+		return
+	}
+	node.Content = append(node.Content, scalar(key), scalar(value))
+}
+
+func scalar(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
@@ -0,0 +1,78 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package cloudformation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetainLogGroup(t *testing.T) {
+	deleted := `Resources:
+  LogGroup:
+    Type: AWS::Logs::LogGroup
+    Properties:
+      LogGroupName: oam-ecs-app-web
+
+  TaskDefinition:
+    Type: AWS::ECS::TaskDefinition
+    Properties:
+      TaskRoleArn: !GetAtt TaskRole.Arn
+      Family: !Sub '${AWS::StackName}-web'
+`
+	retained := `Resources:
+  LogGroup:
+    Type: AWS::Logs::LogGroup
+    Properties:
+      LogGroupName: oam-ecs-app-web
+      Tags:
+        - Key: oam-ecs-kept-logs
+          Value: 'true'
+    DeletionPolicy: Retain
+    UpdateReplacePolicy: Retain
+  TaskDefinition:
+    Type: AWS::ECS::TaskDefinition
+    Properties:
+      TaskRoleArn: !GetAtt TaskRole.Arn
+      Family: !Sub '${AWS::StackName}-web'
+`
+
+	template, changed, err := retainLogGroup(deleted)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Equal(t, retained, template)
+
+	template, changed, err = retainLogGroup(retained)
+	require.NoError(t, err)
+	require.False(t, changed)
+	require.Equal(t, retained, template)
+
+	// A deletion policy of Delete is replaced rather than duplicated
+	template, changed, err = retainLogGroup(`Resources:
+  LogGroup:
+    Type: AWS::Logs::LogGroup
+    DeletionPolicy: Delete
+    Properties:
+      Tags:
+        - Key: team
+          Value: web
+`)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Equal(t, `Resources:
+  LogGroup:
+    Type: AWS::Logs::LogGroup
+    DeletionPolicy: Retain
+    Properties:
+      Tags:
+        - Key: team
+          Value: web
+        - Key: oam-ecs-kept-logs
+          Value: 'true'
+    UpdateReplacePolicy: Retain
+`, template)
+
+	_, _, err = retainLogGroup("Resources:\n  Service:\n")
+	require.EqualError(t, err, "template has no log group")
+}
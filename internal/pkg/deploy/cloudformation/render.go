@@ -0,0 +1,35 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudformation
+
+import (
+	"fmt"
+
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/stack"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/types"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/templates"
+	"github.com/gobuffalo/packd"
+)
+
+// TemplateRenderer renders the CloudFormation templates of oam-ecs resources without an AWS session
+type TemplateRenderer struct {
+	box packd.Box
+}
+
+// NewTemplateRenderer returns a renderer for the templates bundled with oam-ecs.
+func NewTemplateRenderer() TemplateRenderer {
+	return TemplateRenderer{
+		box: templates.Box(),
+	}
+}
+
+// RenderComponent returns the name of the CloudFormation stack for a component instance and the template of the stack
+func (r TemplateRenderer) RenderComponent(component *types.ComponentInput) (string, string, error) {
+	stackConfig := stack.NewComponentStackConfig(component, r.box)
+	template, err := stackConfig.Template()
+	if err != nil {
+		return "", "", fmt.Errorf("template creation: %w", err)
+	}
+	return stackConfig.StackName(), template, nil
+}
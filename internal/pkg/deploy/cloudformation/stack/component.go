@@ -6,6 +6,7 @@ import (
 	"bytes"
 	"fmt"
 	"html/template"
+	"time"
 
 	"github.com/Masterminds/sprig"
 	"github.com/aws/aws-sdk-go/aws"
@@ -101,10 +102,13 @@ func (e *ComponentStackConfig) ToComponent(stack *cloudformation.Stack) (*types.
 		outputs[key] = value
 	}
 
-	createdComponent := types.Component{
-		StackName:    e.StackName(),
-		StackOutputs: outputs,
-	}
+	return types.NewComponent(e.StackName(), aws.StringValue(stack.StackStatus), lastUpdated(stack), outputs), nil
+}
 
-	return &createdComponent, nil
+// lastUpdated returns the time a stack was last updated, or created if it was never updated
+func lastUpdated(stack *cloudformation.Stack) time.Time {
+	if stack.LastUpdatedTime != nil {
+		return *stack.LastUpdatedTime
+	}
+	return aws.TimeValue(stack.CreationTime)
 }
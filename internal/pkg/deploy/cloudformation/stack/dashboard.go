@@ -0,0 +1,203 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package stack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/types"
+	"github.com/gobuffalo/packd"
+)
+
+const (
+	// DashboardTemplatePath is the path where the cloudformation for the dashboard of an application is written.
+	DashboardTemplatePath = "dashboard/cf.yml"
+
+	// DashboardTagKey tags the dashboard stack with the name of its application. Dashboard stacks are not tagged
+	// with AppTagKey, which marks the component instance stacks of an application.
+	DashboardTagKey = "oam-ecs-dashboard"
+
+	// dashboardWidgetHeight and dashboardWidgetWidth are the size of the metric widgets, three to a row
+	dashboardWidgetHeight = 6
+	dashboardWidgetWidth  = 8
+	dashboardWidth        = 24
+	dashboardLogsHeight   = 8
+)
+
+// DashboardStackConfig is for providing all the values to set up the CloudWatch dashboard stack of an application
+// and to interpret the outputs from it.
+type DashboardStackConfig struct {
+	*types.DashboardInput
+	box packd.Box
+}
+
+// NewDashboardStackConfig sets up a struct which can provide values to CloudFormation for
+// spinning up the dashboard of an application.
+func NewDashboardStackConfig(input *types.DashboardInput, box packd.Box) *DashboardStackConfig {
+	return &DashboardStackConfig{
+		DashboardInput: input,
+		box:            box,
+	}
+}
+
+// Template returns the dashboard CloudFormation template.
+func (e *DashboardStackConfig) Template() (string, error) {
+	dashboardTemplate, err := e.box.FindString(DashboardTemplatePath)
+	if err != nil {
+		return "", &ErrTemplateNotFound{templateLocation: DashboardTemplatePath, parentErr: err}
+	}
+
+	template, err := template.New("template").
+		Funcs(map[string]interface{}{"DashboardBody": dashboardBody}).
+		Parse(dashboardTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := template.Execute(&buf, e.DashboardInput); err != nil {
+		return "", err
+	}
+
+	return string(buf.Bytes()), nil
+}
+
+// Parameters returns the parameters to be passed into the dashboard CloudFormation template.
+func (e *DashboardStackConfig) Parameters() []*cloudformation.Parameter {
+	return []*cloudformation.Parameter{}
+}
+
+// Tags returns the tags that should be applied to the dashboard CloudFormation stack.
+func (e *DashboardStackConfig) Tags() []*cloudformation.Tag {
+	return []*cloudformation.Tag{
+		{
+			Key:   aws.String(DashboardTagKey),
+			Value: aws.String(e.ApplicationName),
+		},
+		{
+			Key:   aws.String(EnvTagKey),
+			Value: aws.String(EnvTagValue),
+		},
+	}
+}
+
+// StackName returns the name of the CloudFormation stack (hard-coded).
+func (e *DashboardStackConfig) StackName() string {
+	return fmt.Sprintf("oam-ecs-%s-dashboard", e.ApplicationName)
+}
+
+// ToDashboard inspects a dashboard cloudformation stack and constructs a dashboard struct out of it
+func (e *DashboardStackConfig) ToDashboard(stack *cloudformation.Stack) (*types.Dashboard, error) {
+	outputs := map[string]string{}
+
+	for _, output := range stack.Outputs {
+		key := *output.OutputKey
+		value := *output.OutputValue
+		outputs[key] = value
+	}
+
+	return &types.Dashboard{
+		StackName:    e.StackName(),
+		StackStatus:  aws.StringValue(stack.StackStatus),
+		LastUpdated:  lastUpdated(stack),
+		URL:          outputs[types.DashboardConsoleOutputKey],
+		StackOutputs: outputs,
+	}, nil
+}
+
+type dashboardWidget struct {
+	Type       string                    `json:"type"`
+	X          int                       `json:"x"`
+	Y          int                       `json:"y"`
+	Width      int                       `json:"width"`
+	Height     int                       `json:"height"`
+	Properties dashboardWidgetProperties `json:"properties"`
+}
+
+type dashboardWidgetProperties struct {
+	Title   string          `json:"title"`
+	Region  string          `json:"region"`
+	View    string          `json:"view,omitempty"`
+	Stat    string          `json:"stat,omitempty"`
+	Period  int             `json:"period,omitempty"`
+	Metrics [][]interface{} `json:"metrics,omitempty"`
+	Query   string          `json:"query,omitempty"`
+}
+
+// dashboardBody returns the JSON body of the dashboard: a row of CPU and memory, task count and load balancer traffic
+// widgets for each component instance, and a Logs Insights widget on the log groups of all the component instances.
+// The region is left for the template to substitute.
+func dashboardBody(input *types.DashboardInput) (template.HTML, error) {
+	region := "${AWS::Region}"
+	cluster := input.Environment.Name
+
+	widgets := []dashboardWidget{}
+	sources := []string{}
+	for i, component := range input.Components {
+		y := i * dashboardWidgetHeight
+		service := []interface{}{"ClusterName", cluster, "ServiceName", component.ServiceName}
+
+		widgets = append(widgets, dashboardWidget{
+			Type: "metric", X: 0, Y: y, Width: dashboardWidgetWidth, Height: dashboardWidgetHeight,
+			Properties: dashboardWidgetProperties{
+				Title:  fmt.Sprintf("%s CPU and memory utilization", component.InstanceName),
+				Region: region, View: "timeSeries", Stat: "Average", Period: 60,
+				Metrics: [][]interface{}{
+					append([]interface{}{"AWS/ECS", "CPUUtilization"}, service...),
+					append([]interface{}{"AWS/ECS", "MemoryUtilization"}, service...),
+				},
+			},
+		})
+		widgets = append(widgets, dashboardWidget{
+			Type: "metric", X: dashboardWidgetWidth, Y: y, Width: dashboardWidgetWidth, Height: dashboardWidgetHeight,
+			Properties: dashboardWidgetProperties{
+				Title:  fmt.Sprintf("%s tasks", component.InstanceName),
+				Region: region, View: "timeSeries", Stat: "Average", Period: 60,
+				Metrics: [][]interface{}{
+					append([]interface{}{"ECS/ContainerInsights", "DesiredTaskCount"}, service...),
+					append([]interface{}{"ECS/ContainerInsights", "RunningTaskCount"}, service...),
+					append([]interface{}{"ECS/ContainerInsights", "PendingTaskCount"}, service...),
+				},
+			},
+		})
+		if component.LoadBalancerFullName != "" {
+			loadBalancer := []interface{}{"LoadBalancer", component.LoadBalancerFullName}
+			widgets = append(widgets, dashboardWidget{
+				Type: "metric", X: 2 * dashboardWidgetWidth, Y: y, Width: dashboardWidgetWidth, Height: dashboardWidgetHeight,
+				Properties: dashboardWidgetProperties{
+					Title:  fmt.Sprintf("%s load balancer traffic", component.InstanceName),
+					Region: region, View: "timeSeries", Stat: "Sum", Period: 60,
+					Metrics: [][]interface{}{
+						append([]interface{}{"AWS/NetworkELB", "NewFlowCount"}, loadBalancer...),
+						append([]interface{}{"AWS/NetworkELB", "ActiveFlowCount"}, loadBalancer...),
+						append([]interface{}{"AWS/NetworkELB", "ProcessedBytes"}, loadBalancer...),
+					},
+				},
+			})
+		}
+
+		sources = append(sources, fmt.Sprintf("SOURCE '%s'", component.LogGroupName))
+	}
+
+	widgets = append(widgets, dashboardWidget{
+		Type: "log", X: 0, Y: len(input.Components) * dashboardWidgetHeight, Width: dashboardWidth, Height: dashboardLogsHeight,
+		Properties: dashboardWidgetProperties{
+			Title:  fmt.Sprintf("%s logs", input.ApplicationName),
+			Region: region, View: "table",
+			Query: strings.Join(sources, " | ") + " | fields @timestamp, @logStream, @message | sort @timestamp desc | limit 100",
+		},
+	})
+
+	body, err := json.Marshal(map[string]interface{}{"widgets": widgets})
+	if err != nil {
+		return "", err
+	}
+	// The body is a block scalar of the template, and is not HTML
+	return template.HTML(body), nil
+}
@@ -4,6 +4,7 @@ package stack
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
@@ -23,6 +24,21 @@ const (
 	EnvTemplatePath = "environment/cf.yml"
 )
 
+// Parameter keys and values of the environment template.
+const (
+	envParamEC2CapacityEnabledKey = "EC2CapacityEnabled"
+	envParamEC2InstanceTypeKey    = "EC2InstanceType"
+	envParamEC2AmiTypeKey         = "EC2AmiType"
+	envParamEC2MaxSizeKey         = "EC2MaxSize"
+	envParamEFSEnabledKey         = "EFSEnabled"
+	envParamLogRetentionKey       = "LogRetentionInDays"
+	envParamLogKMSKeyARNKey       = "LogKmsKeyArn"
+	envParamLogDeletionPolicyKey  = "LogDeletionPolicy"
+
+	ec2AmiTypeStandard = "standard"
+	ec2AmiTypeGPU      = "gpu"
+)
+
 // NewEnvStackConfig sets up a struct which can provide values to CloudFormation for
 // spinning up an environment.
 func NewEnvStackConfig(input *types.EnvironmentInput, box packd.Box) *EnvStackConfig {
@@ -43,8 +59,65 @@ func (e *EnvStackConfig) Template() (string, error) {
 }
 
 // Parameters returns the parameters to be passed into a environment CloudFormation template.
+// A setting that was not given keeps its previous value if the deployed stack has the parameter,
+// and is left out otherwise so that the template default applies.
 func (e *EnvStackConfig) Parameters() []*cloudformation.Parameter {
-	return []*cloudformation.Parameter{}
+	var amiType *string
+	if e.EC2GPU != nil {
+		amiType = aws.String(ec2AmiTypeStandard)
+		if *e.EC2GPU {
+			amiType = aws.String(ec2AmiTypeGPU)
+		}
+	}
+	deletionPolicy := e.LogDeletionPolicy
+	if deletionPolicy != nil && *deletionPolicy == "" {
+		deletionPolicy = aws.String("Delete")
+	}
+
+	settings := []struct {
+		key   string
+		value *string
+	}{
+		{envParamEC2CapacityEnabledKey, formatBool(e.EC2Capacity)},
+		{envParamEC2InstanceTypeKey, e.EC2InstanceType},
+		{envParamEC2AmiTypeKey, amiType},
+		{envParamEC2MaxSizeKey, formatInt(e.EC2MaxSize)},
+		{envParamEFSEnabledKey, formatBool(e.FileSystem)},
+		{envParamLogRetentionKey, formatInt(e.LogRetentionInDays)},
+		{envParamLogKMSKeyARNKey, e.LogKMSKeyARN},
+		{envParamLogDeletionPolicyKey, deletionPolicy},
+	}
+
+	var params []*cloudformation.Parameter
+	for _, setting := range settings {
+		switch {
+		case setting.value != nil:
+			params = append(params, &cloudformation.Parameter{
+				ParameterKey:   aws.String(setting.key),
+				ParameterValue: setting.value,
+			})
+		case e.ExistingParameters[setting.key]:
+			params = append(params, &cloudformation.Parameter{
+				ParameterKey:     aws.String(setting.key),
+				UsePreviousValue: aws.Bool(true),
+			})
+		}
+	}
+	return params
+}
+
+func formatBool(value *bool) *string {
+	if value == nil {
+		return nil
+	}
+	return aws.String(strconv.FormatBool(*value))
+}
+
+func formatInt(value *int) *string {
+	if value == nil {
+		return nil
+	}
+	return aws.String(strconv.Itoa(*value))
 }
 
 // Tags returns the tags that should be applied to the environment CloudFormation stack.
@@ -75,6 +148,8 @@ func (e *EnvStackConfig) ToEnv(stack *cloudformation.Stack) (*types.Environment,
 
 	createdEnv := types.Environment{
 		StackName:    e.StackName(),
+		StackStatus:  aws.StringValue(stack.StackStatus),
+		LastUpdated:  lastUpdated(stack),
 		StackOutputs: outputs,
 	}
 
@@ -0,0 +1,87 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package stack
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvStackConfigParameters(t *testing.T) {
+	allParameters := map[string]bool{
+		envParamEC2CapacityEnabledKey: true,
+		envParamEC2InstanceTypeKey:    true,
+		envParamEC2AmiTypeKey:         true,
+		envParamEC2MaxSizeKey:         true,
+		envParamEFSEnabledKey:         true,
+		envParamLogRetentionKey:       true,
+		envParamLogKMSKeyARNKey:       true,
+		envParamLogDeletionPolicyKey:  true,
+	}
+
+	tests := map[string]struct {
+		input    types.EnvironmentInput
+		expected []*cloudformation.Parameter
+	}{
+		"new environment without settings takes the defaults": {
+			input:    types.EnvironmentInput{},
+			expected: nil,
+		},
+		"new environment with settings": {
+			input: types.EnvironmentInput{
+				EC2Capacity:       aws.Bool(true),
+				EC2GPU:            aws.Bool(true),
+				FileSystem:        aws.Bool(false),
+				LogDeletionPolicy: aws.String(""),
+			},
+			expected: []*cloudformation.Parameter{
+				{ParameterKey: aws.String(envParamEC2CapacityEnabledKey), ParameterValue: aws.String("true")},
+				{ParameterKey: aws.String(envParamEC2AmiTypeKey), ParameterValue: aws.String(ec2AmiTypeGPU)},
+				{ParameterKey: aws.String(envParamEFSEnabledKey), ParameterValue: aws.String("false")},
+				{ParameterKey: aws.String(envParamLogDeletionPolicyKey), ParameterValue: aws.String("Delete")},
+			},
+		},
+		"existing environment keeps the settings that are not given": {
+			input: types.EnvironmentInput{
+				EC2MaxSize:         aws.Int(8),
+				LogRetentionInDays: aws.Int(30),
+				ExistingParameters: allParameters,
+			},
+			expected: []*cloudformation.Parameter{
+				{ParameterKey: aws.String(envParamEC2CapacityEnabledKey), UsePreviousValue: aws.Bool(true)},
+				{ParameterKey: aws.String(envParamEC2InstanceTypeKey), UsePreviousValue: aws.Bool(true)},
+				{ParameterKey: aws.String(envParamEC2AmiTypeKey), UsePreviousValue: aws.Bool(true)},
+				{ParameterKey: aws.String(envParamEC2MaxSizeKey), ParameterValue: aws.String("8")},
+				{ParameterKey: aws.String(envParamEFSEnabledKey), UsePreviousValue: aws.Bool(true)},
+				{ParameterKey: aws.String(envParamLogRetentionKey), ParameterValue: aws.String("30")},
+				{ParameterKey: aws.String(envParamLogKMSKeyARNKey), UsePreviousValue: aws.Bool(true)},
+				{ParameterKey: aws.String(envParamLogDeletionPolicyKey), UsePreviousValue: aws.Bool(true)},
+			},
+		},
+		"existing environment deployed before a parameter was added takes its default": {
+			input: types.EnvironmentInput{
+				EC2Capacity: aws.Bool(false),
+				ExistingParameters: map[string]bool{
+					envParamEC2CapacityEnabledKey: true,
+					envParamEC2InstanceTypeKey:    true,
+				},
+			},
+			expected: []*cloudformation.Parameter{
+				{ParameterKey: aws.String(envParamEC2CapacityEnabledKey), ParameterValue: aws.String("false")},
+				{ParameterKey: aws.String(envParamEC2InstanceTypeKey), UsePreviousValue: aws.Bool(true)},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			input := tc.input
+			config := NewEnvStackConfig(&input, nil)
+			require.Equal(t, tc.expected, config.Parameters())
+		})
+	}
+}
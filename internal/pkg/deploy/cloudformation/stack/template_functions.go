@@ -3,19 +3,68 @@
 package stack
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"html/template"
+	"math"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
 
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/deploy/cloudformation/types"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/workload"
 	"github.com/oam-dev/oam-go-sdk/apis/core.oam.dev/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 var templateFunctions = map[string]interface{}{
 	"ResolveParameterValue":       resolveOAMParameterValue,
 	"ResolveTraitValue":           resolveOAMTraitValue,
+	"ResolveTraitStringValue":     resolveOAMTraitStringValue,
 	"TaskCPU":                     resolveTaskCpuValue,
 	"TaskMemory":                  resolveTaskMemoryValue,
+	"TaskEphemeralStorage":        resolveTaskEphemeralStorageValue,
 	"RequiresVolumes":             hasAnyVolumes,
 	"RequiresPrivateRegistryAuth": hasAnyPullSecrets,
 	"HealthCheckGracePeriod":      resolveHealthCheckGracePeriod,
+	"RequiresEC2":                 requiresEC2,
+	"TaskVolumes":                 uniqueVolumes,
+	"IsFileSystemVolume":          isFileSystemVolume,
+	"IsHostVolume":                isHostVolume,
+	"RequiresFileSystem":          hasAnyFileSystemVolumes,
+	"ContainerEnvironment":        resolveContainerEnvironment,
+	"ContainerSecrets":            resolveContainerSecrets,
+	"ExecutionRoleSecrets":        resolveExecutionRoleSecrets,
+	"SecretsManagerResource":      secretsManagerResource,
+	"ConfigFiles":                 resolveConfigFiles,
+	"ConfigVolumes":               resolveConfigVolumes,
+	"ConfigFilesHash":             resolveConfigFilesHash,
+	"ContainerDependencies":       resolveContainerDependencies,
+	"ContainerHealthCheck":        resolveContainerHealthCheck,
+	"TargetGroupHealthCheck":      resolveTargetGroupHealthCheck,
+	"RequiresHealthCheckHelper":   requiresHealthCheckHelper,
+	"TaskRolePermissions":         resolveTaskRolePermissions,
+	"PolicyString":                policyString,
+	"QuotedString":                quotedString,
+	"LoadBalancerIngress":         resolveLoadBalancerIngress,
+	"ContainerIngressRules":       resolveContainerIngressRules,
+	"DiscoveryIngressRules":       resolveDiscoveryIngressRules,
+	"DiscoveryHostname":           workload.DiscoveryHostname,
+	"EndpointProtocol":            resolveEndpointProtocol,
+	"Sidecars":                    resolveSidecars,
+	"Logging":                     resolveLogging,
+	"ContainerLogConfiguration":   resolveContainerLogConfiguration,
+	"LogGroupSettings":            resolveLogGroupSettings,
+	"Monitoring":                  resolveMonitoring,
+	"Tracing":                     resolveTracing,
+	"Exec":                        resolveExec,
+	"SidecarEnvironment":          resolveSidecarEnvironment,
+	"SidecarSecrets":              resolveSidecarSecrets,
 }
 
 // resolveOAMParameterValue finds the value of a named parameter
@@ -53,6 +102,446 @@ func resolveOAMTraitValue(traitName string, propertyName string, defaultValue in
 	return defaultValue
 }
 
+// resolveOAMTraitStringValue finds the string value of a named trait property
+// for a given component instance configuration
+func resolveOAMTraitStringValue(traitName string, propertyName string, defaultValue string, componentConfiguration *v1alpha1.ComponentConfiguration) string {
+	properties, err := traitProperties(traitName, componentConfiguration)
+	if err != nil || properties == nil {
+		return defaultValue
+	}
+
+	if val, ok := properties[propertyName].(string); ok && val != "" {
+		return val
+	}
+
+	return defaultValue
+}
+
+// traitProperties returns the properties of a named trait for a given component instance configuration,
+// or nil if the trait is not bound to the component instance
+func traitProperties(traitName string, componentConfiguration *v1alpha1.ComponentConfiguration) (map[string]interface{}, error) {
+	for _, trait := range componentConfiguration.Traits {
+		if trait.Name != traitName {
+			continue
+		}
+
+		properties := make(map[string]interface{})
+		if len(trait.Properties.Raw) == 0 {
+			return properties, nil
+		}
+		if err := json.Unmarshal(trait.Properties.Raw, &properties); err != nil {
+			return nil, fmt.Errorf("Could not parse the properties of trait %s: %w", traitName, err)
+		}
+		return properties, nil
+	}
+
+	return nil, nil
+}
+
+// resolveTaskRolePermissions finds the IAM policy statements and managed policies granted to the task role
+// by the permissions trait, or nil if the trait is not bound to the component instance
+func resolveTaskRolePermissions(componentConfiguration *v1alpha1.ComponentConfiguration) (*workload.Permissions, error) {
+	properties, err := traitProperties(workload.PermissionsTrait, componentConfiguration)
+	if err != nil || properties == nil {
+		return nil, err
+	}
+
+	permissions, problems := workload.ParsePermissions(properties)
+	if len(problems) > 0 {
+		return nil, traitPropertiesError(workload.PermissionsTrait, problems)
+	}
+	return permissions, nil
+}
+
+// substitutionPattern matches the ${...} substitutions of Fn::Sub strings
+var substitutionPattern = regexp.MustCompile(`\$\{[^}]*\}`)
+
+// quotedString renders a value as a single-quoted YAML scalar, for values given by the user that the template
+// must not interpret
+func quotedString(value string) template.HTML {
+	return template.HTML("'" + strings.ReplaceAll(value, "'", "''") + "'")
+}
+
+// policyString renders a value given by the user, like an ARN or a value of the permissions trait, as a YAML scalar.
+// Values that refer to the pseudo parameters of the stack, as ${AWS::Partition}, are substituted with !Sub, keeping
+// any other ${...}, such as the IAM policy variable ${aws:username}, as written. Other values are quoted as they are.
+func policyString(value string) template.HTML {
+	if !strings.Contains(value, "${AWS::") {
+		return quotedString(value)
+	}
+	substituted := substitutionPattern.ReplaceAllStringFunc(value, func(substitution string) string {
+		if strings.HasPrefix(substitution, "${AWS::") {
+			return substitution
+		}
+		return "${!" + strings.TrimPrefix(substitution, "${")
+	})
+	return "!Sub " + quotedString(substituted)
+}
+
+// resolveSidecars finds the containers added to the task by the sidecar trait, after the log router if the logging
+// trait routes the logs with FireLens, and before the X-Ray daemon if the tracing trait is bound. Returns an empty
+// list if none adds containers to the task.
+func resolveSidecars(componentConfiguration *v1alpha1.ComponentConfiguration) ([]*workload.Sidecar, error) {
+	sidecars := []*workload.Sidecar{}
+
+	logging, err := resolveLogging(componentConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	if logging.Driver == workload.FireLensDriver {
+		sidecars = append(sidecars, workload.LogRouter())
+	}
+
+	tracing, err := resolveTracing(componentConfiguration)
+	if err != nil {
+		return nil, err
+	}
+
+	properties, err := traitProperties(workload.SidecarTrait, componentConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	if properties != nil {
+		traitSidecars, problems := workload.ParseSidecars(properties)
+		if len(problems) > 0 {
+			return nil, traitPropertiesError(workload.SidecarTrait, problems)
+		}
+		sidecars = append(sidecars, traitSidecars...)
+	}
+
+	// The tracing trait uses the X-Ray daemon of the sidecar trait, if it adds one
+	if tracing != nil {
+		for _, sidecar := range sidecars {
+			if sidecar.Name == tracing.DaemonName {
+				return sidecars, nil
+			}
+		}
+		sidecars = append(sidecars, workload.XRayDaemon())
+	}
+	return sidecars, nil
+}
+
+// resolveTracing finds the settings of the tracing trait, or nil if the trait is not bound to the component instance
+func resolveTracing(componentConfiguration *v1alpha1.ComponentConfiguration) (*workload.Tracing, error) {
+	properties, err := traitProperties(workload.TracingTrait, componentConfiguration)
+	if err != nil || properties == nil {
+		return nil, err
+	}
+
+	tracing, problems := workload.ParseTracing(properties)
+	if len(problems) > 0 {
+		return nil, traitPropertiesError(workload.TracingTrait, problems)
+	}
+	return tracing, nil
+}
+
+// resolveExec finds whether the exec trait is bound to the component instance, which enables ECS Exec in its tasks
+func resolveExec(componentConfiguration *v1alpha1.ComponentConfiguration) (bool, error) {
+	properties, err := traitProperties(workload.ExecTrait, componentConfiguration)
+	if err != nil || properties == nil {
+		return false, err
+	}
+
+	if problems := workload.ParseExec(properties); problems != nil {
+		return false, traitPropertiesError(workload.ExecTrait, problems)
+	}
+	return true, nil
+}
+
+// resolveLogging finds the log settings of the logging trait, or the default settings if the trait
+// is not bound to the component instance
+func resolveLogging(componentConfiguration *v1alpha1.ComponentConfiguration) (*workload.Logging, error) {
+	properties, err := traitProperties(workload.LoggingTrait, componentConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	if properties == nil {
+		return workload.DefaultLogging(), nil
+	}
+
+	logging, problems := workload.ParseLogging(properties)
+	if len(problems) > 0 {
+		return nil, traitPropertiesError(workload.LoggingTrait, problems)
+	}
+	return logging, nil
+}
+
+// resolveLoadBalancerIngress finds the sources allowed to reach the load balancer by the ingress-policy trait,
+// or the default sources for the load balancer's exposure if the trait is not bound to the component instance
+func resolveLoadBalancerIngress(componentConfiguration *v1alpha1.ComponentConfiguration) (*workload.IngressPolicy, error) {
+	properties, err := traitProperties(workload.IngressPolicyTrait, componentConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	if properties == nil {
+		exposure := resolveOAMTraitStringValue(workload.ExposureTrait, "type", workload.PublicExposure, componentConfiguration)
+		return workload.DefaultIngressPolicy(exposure), nil
+	}
+
+	policy, problems := workload.ParseIngressPolicy(properties)
+	if len(problems) > 0 {
+		return nil, traitPropertiesError(workload.IngressPolicyTrait, problems)
+	}
+	return policy, nil
+}
+
+// resolveMonitoring finds the alarm thresholds and notification targets of the monitoring trait,
+// or nil if the trait is not bound to the component instance
+func resolveMonitoring(componentConfiguration *v1alpha1.ComponentConfiguration) (*workload.Monitoring, error) {
+	properties, err := traitProperties(workload.MonitoringTrait, componentConfiguration)
+	if err != nil || properties == nil {
+		return nil, err
+	}
+
+	monitoring, problems := workload.ParseMonitoring(properties)
+	if len(problems) > 0 {
+		return nil, traitPropertiesError(workload.MonitoringTrait, problems)
+	}
+	return monitoring, nil
+}
+
+// resolveLogGroupSettings applies the log group settings of the logging trait over the environment's defaults
+func resolveLogGroupSettings(logging *workload.Logging, environment *types.ComponentEnvironment) *types.LogSettings {
+	settings := &types.LogSettings{}
+	if environment.Logs != nil {
+		*settings = *environment.Logs
+	}
+
+	if logging.RetentionInDays != 0 {
+		settings.RetentionInDays = logging.RetentionInDays
+	}
+	if logging.KMSKeyARN != "" {
+		settings.KMSKeyARN = logging.KMSKeyARN
+	}
+	if logging.DeletionPolicy != "" {
+		settings.DeletionPolicy = logging.DeletionPolicy
+	}
+	return settings
+}
+
+// containerLogConfiguration is the LogConfiguration of the containers and the sidecars of a task
+type containerLogConfiguration struct {
+	LogDriver string
+	Options   []logOption
+}
+
+// logOption is an option of a container log configuration, whose value is either a string or a reference
+// to a resource or a pseudo parameter
+type logOption struct {
+	Name  string
+	Value string
+	Ref   string
+}
+
+// resolveContainerLogConfiguration translates the log settings to the log configuration of the containers and the
+// sidecars, or nil if their logs are dropped. The firelens driver sends the logs to the log router, which writes them
+// to the destination with the matching Fluent Bit output plugin.
+func resolveContainerLogConfiguration(logging *workload.Logging, environmentName string, applicationName string, instanceName string) *containerLogConfiguration {
+	switch logging.Driver {
+	case workload.NoLogsDriver:
+		return nil
+	case workload.FireLensDriver:
+		configuration := &containerLogConfiguration{LogDriver: "awsfirelens"}
+		switch logging.Destination {
+		case workload.S3LogDestination:
+			prefix := ""
+			if logging.Prefix != "" {
+				prefix = strings.Trim(logging.Prefix, "/") + "/"
+			}
+			configuration.Options = []logOption{
+				{Name: "Name", Value: "s3"},
+				{Name: "region", Ref: "AWS::Region"},
+				{Name: "bucket", Value: logging.Bucket},
+				{Name: "total_file_size", Value: "1M"},
+				{Name: "upload_timeout", Value: "1m"},
+				{Name: "use_put_object", Value: "On"},
+				{Name: "s3_key_format", Value: fmt.Sprintf("/%s%s-%s-%s/$TAG/%%Y/%%m/%%d/%%H%%M%%S-$UUID", prefix, environmentName, applicationName, instanceName)},
+			}
+		case workload.FirehoseLogDestination:
+			configuration.Options = []logOption{
+				{Name: "Name", Value: "kinesis_firehose"},
+				{Name: "region", Ref: "AWS::Region"},
+				{Name: "delivery_stream", Value: logging.DeliveryStream},
+			}
+		case workload.OpenSearchLogDestination:
+			configuration.Options = []logOption{
+				{Name: "Name", Value: "opensearch"},
+				{Name: "Host", Value: logging.Endpoint},
+				{Name: "Port", Value: "443"},
+				{Name: "Index", Value: logging.Index},
+				{Name: "AWS_Auth", Value: "On"},
+				{Name: "AWS_Region", Ref: "AWS::Region"},
+				{Name: "tls", Value: "On"},
+				{Name: "Suppress_Type_Name", Value: "On"},
+			}
+		}
+		return configuration
+	default:
+		return &containerLogConfiguration{
+			LogDriver: "awslogs",
+			Options: []logOption{
+				{Name: "awslogs-region", Ref: "AWS::Region"},
+				{Name: "awslogs-group", Ref: "LogGroup"},
+				{Name: "awslogs-stream-prefix", Value: "oam-ecs"},
+			},
+		}
+	}
+}
+
+// traitPropertiesError reports the first invalid property of a trait, in property path order
+func traitPropertiesError(traitName string, problems map[string]string) error {
+	names := make([]string, 0, len(problems))
+	for name := range problems {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return fmt.Errorf("Invalid property %s of trait %s: %s", names[0], traitName, problems[names[0]])
+}
+
+type ingressRule struct {
+	Protocol string
+	Port     int32
+}
+
+// resolveContainerIngressRules lists the protocols and ports on which the public load balancer reaches the containers
+// of a Server component: the container ports for traffic, and the target group health check ports. Target groups
+// without a health check probe check the traffic port over TCP, even for UDP ports.
+func resolveContainerIngressRules(componentSpec *v1alpha1.ComponentSpec) []ingressRule {
+	rules := []ingressRule{}
+	if componentSpec.WorkloadType != "core.oam.dev/v1alpha1.Server" {
+		return rules
+	}
+
+	seen := make(map[ingressRule]bool)
+	add := func(rule ingressRule) {
+		if !seen[rule] {
+			seen[rule] = true
+			rules = append(rules, rule)
+		}
+	}
+	for _, container := range componentSpec.Containers {
+		probe := resolveTargetGroupHealthCheck(container, componentSpec.WorkloadType)
+		for _, port := range container.Ports {
+			protocol := "tcp"
+			if port.Protocol != "" {
+				protocol = strings.ToLower(string(port.Protocol))
+			}
+			add(ingressRule{Protocol: protocol, Port: port.ContainerPort})
+			if probe == nil && protocol != "tcp" {
+				add(ingressRule{Protocol: "tcp", Port: port.ContainerPort})
+			}
+		}
+		if probe != nil {
+			if probe.HttpGet != nil && probe.HttpGet.Port > 0 {
+				add(ingressRule{Protocol: "tcp", Port: probe.HttpGet.Port})
+			}
+			if probe.TcpSocket != nil && probe.TcpSocket.Port > 0 {
+				add(ingressRule{Protocol: "tcp", Port: probe.TcpSocket.Port})
+			}
+		}
+	}
+
+	return rules
+}
+
+// resolveComponentIngressRules lists the protocols and ports on which the clients of a Server component reach its
+// containers directly, through the hostname the component is registered under in Cloud Map. Worker components
+// are not network-accessible, so they accept no traffic from other components.
+func resolveComponentIngressRules(componentSpec *v1alpha1.ComponentSpec) []ingressRule {
+	rules := []ingressRule{}
+	if componentSpec.WorkloadType != "core.oam.dev/v1alpha1.Server" {
+		return rules
+	}
+	seen := make(map[ingressRule]bool)
+	for _, container := range componentSpec.Containers {
+		for _, port := range container.Ports {
+			protocol := "tcp"
+			if port.Protocol != "" {
+				protocol = strings.ToLower(string(port.Protocol))
+			}
+			rule := ingressRule{Protocol: protocol, Port: port.ContainerPort}
+			if !seen[rule] {
+				seen[rule] = true
+				rules = append(rules, rule)
+			}
+		}
+	}
+	return rules
+}
+
+type discoveryIngressRule struct {
+	ingressRule
+	// Resource is the logical ID of the AWS::EC2::SecurityGroupIngress resource, unique to the pair of component
+	// instances and the port so that the rule keeps its ID as the other rules change
+	Resource string
+	Client   string
+	Server   string
+}
+
+// resolveDiscoveryIngressRules lists the rules that let the clients of Server component instances reach their
+// container ports through their discovery hostnames, for the references between the component instance and the
+// component instances deployed before it. References to component instances that are not Server components, or
+// whose schematics are unknown, have no rules.
+func resolveDiscoveryIngressRules(application *v1alpha1.ApplicationConfiguration, instanceName string, schematics map[string]*v1alpha1.ComponentSchematic) []discoveryIngressRule {
+	componentNames := make(map[string]string)
+	for _, component := range application.Spec.Components {
+		componentNames[component.InstanceName] = component.ComponentName
+	}
+
+	rules := []discoveryIngressRule{}
+	for _, reference := range workload.DiscoveryReferences(application, instanceName) {
+		schematic, ok := schematics[componentNames[reference.Server]]
+		if !ok || schematic == nil {
+			continue
+		}
+		for _, rule := range resolveComponentIngressRules(&schematic.Spec) {
+			rules = append(rules, discoveryIngressRule{
+				ingressRule: rule,
+				Resource:    fmt.Sprintf("DiscoveryIngress%sTo%s%s%d", logicalIDPart(reference.Client), logicalIDPart(reference.Server), strings.ToUpper(rule.Protocol), rule.Port),
+				Client:      reference.Client,
+				Server:      reference.Server,
+			})
+		}
+	}
+	return rules
+}
+
+// logicalIDPart turns a name like search-indexer into SearchIndexer, for use in the logical ID of a resource
+func logicalIDPart(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// resolveEndpointProtocol returns the protocol of the load balancer endpoint of a container port: udp for UDP
+// ports, http for ports named http or http-<suffix> and for the ports the container's probes send HTTP requests to,
+// and tcp otherwise
+func resolveEndpointProtocol(container v1alpha1.Container, port v1alpha1.Port) string {
+	if strings.EqualFold(string(port.Protocol), "udp") {
+		return "udp"
+	}
+	if name := strings.ToLower(port.Name); name == "http" || strings.HasPrefix(name, "http-") {
+		return "http"
+	}
+	for _, probe := range []*v1alpha1.HealthProbe{container.LivenessProbe, container.ReadinessProbe} {
+		if probe != nil && probe.HttpGet != nil && probe.HttpGet.Port == port.ContainerPort {
+			return "http"
+		}
+	}
+	return "tcp"
+}
+
 // hasAnyVolumes checks whether at least one of the containers requires a volume
 func hasAnyVolumes(containers []v1alpha1.Container) bool {
 	hasVolumes := false
@@ -67,6 +556,73 @@ func hasAnyVolumes(containers []v1alpha1.Container) bool {
 	return hasVolumes
 }
 
+// uniqueVolumes lists the task volumes for the containers, since the same volume
+// can be mounted into multiple containers
+func uniqueVolumes(containers []v1alpha1.Container) []v1alpha1.Volume {
+	volumes := []v1alpha1.Volume{}
+	seen := make(map[string]bool)
+
+	for _, container := range containers {
+		for _, volume := range container.Resources.Volumes {
+			if seen[volume.Name] {
+				continue
+			}
+			seen[volume.Name] = true
+			volumes = append(volumes, volume)
+		}
+	}
+
+	return volumes
+}
+
+// isPersistentVolume checks whether a volume must persist beyond the lifetime of a task
+// or be shared between tasks
+func isPersistentVolume(volume v1alpha1.Volume) bool {
+	if volume.SharingPolicy == v1alpha1.Shared {
+		return true
+	}
+	return volume.Disk != nil && !volume.Disk.Ephemeral
+}
+
+// isHostVolume checks whether a volume is a host volume on the EC2 container instance, which is the case
+// for the persistent volumes that are not shared when the efs trait selects the host file system
+func isHostVolume(volume v1alpha1.Volume, hostVolumes bool) bool {
+	return hostVolumes && volume.SharingPolicy != v1alpha1.Shared && isPersistentVolume(volume)
+}
+
+// isFileSystemVolume checks whether a volume is a persistent volume that requires an EFS file system
+func isFileSystemVolume(volume v1alpha1.Volume, hostVolumes bool) bool {
+	return isPersistentVolume(volume) && !isHostVolume(volume, hostVolumes)
+}
+
+// hasAnyFileSystemVolumes checks whether at least one of the containers requires an EFS volume
+func hasAnyFileSystemVolumes(containers []v1alpha1.Container, hostVolumes bool) bool {
+	for _, volume := range uniqueVolumes(containers) {
+		if isFileSystemVolume(volume, hostVolumes) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// requiresEC2 checks whether at least one of the containers requests resources that Fargate
+// cannot provide (GPUs or host volumes), and so the task must be placed on EC2 container instances
+func requiresEC2(containers []v1alpha1.Container, hostVolumes bool) bool {
+	for _, container := range containers {
+		if !container.Resources.Gpu.Required.IsZero() {
+			return true
+		}
+		for _, volume := range container.Resources.Volumes {
+			if isHostVolume(volume, hostVolumes) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // hasAnyPullSecrets checks whether at least one of the containers provides an image pull secret
 func hasAnyPullSecrets(containers []v1alpha1.Container) bool {
 	hasAnyPullSecrets := false
@@ -81,14 +637,565 @@ func hasAnyPullSecrets(containers []v1alpha1.Container) bool {
 	return hasAnyPullSecrets
 }
 
-// resolveHealthCheckGracePeriod finds the max grace period across all containers
-func resolveHealthCheckGracePeriod(containers []v1alpha1.Container) int32 {
-	gracePeriod := int32(0)
+const (
+	secretsManagerSourcePrefix = "secretsmanager:"
+	ssmSourcePrefix            = "ssm:"
+
+	secretsManagerARNFormat = "arn:${AWS::Partition}:secretsmanager:${AWS::Region}:${AWS::AccountId}:secret:%s"
+	ssmParameterARNFormat   = "arn:${AWS::Partition}:ssm:${AWS::Region}:${AWS::AccountId}:parameter/%s"
+)
+
+type containerEnvVar struct {
+	Name  string
+	Value string
+	// ImportValue is the name of the CloudFormation export that gives the value, set instead of Value
+	ImportValue string
+	// DiscoveryInstance is the component instance whose discovery hostname is the value, set instead of Value
+	DiscoveryInstance string
+}
+
+type containerSecret struct {
+	Name      string
+	ValueFrom string
+}
+
+// executionRoleSecrets lists the resources the task execution role needs read access to,
+// in order to inject the containers' secrets
+type executionRoleSecrets struct {
+	SecretsManager []string
+	SSM            []string
+	// ConfigFiles are the ARNs of the config file parameters, which refer to the parameter resources of the stack
+	ConfigFiles []string
+}
+
+// resolveEnvValue finds the value of a container environment variable, either given directly
+// or through a component parameter
+func resolveEnvValue(env v1alpha1.Env, componentConfiguration *v1alpha1.ComponentConfiguration, componentSpec *v1alpha1.ComponentSpec) (string, error) {
+	if env.FromParam != "" {
+		return resolveOAMParameterValue(env.FromParam, componentConfiguration, componentSpec)
+	}
+	return env.Value, nil
+}
+
+// isSecretReference checks whether an environment variable value references a secret
+// in Secrets Manager or a parameter in SSM Parameter Store, rather than being a plain text value
+func isSecretReference(value string) bool {
+	return strings.HasPrefix(value, secretsManagerSourcePrefix) || strings.HasPrefix(value, ssmSourcePrefix)
+}
+
+// resolveContainerEnvironment lists the plain text environment variables for a container
+func resolveContainerEnvironment(container v1alpha1.Container, componentConfiguration *v1alpha1.ComponentConfiguration, componentSpec *v1alpha1.ComponentSpec) ([]containerEnvVar, error) {
+	environment := []containerEnvVar{}
+
+	for _, env := range container.Env {
+		value, err := resolveEnvValue(env, componentConfiguration, componentSpec)
+		if err != nil {
+			return nil, err
+		}
+		if isSecretReference(value) {
+			continue
+		}
+		if expression, ok := workload.ParseParameterExpression(value); ok && env.FromParam != "" {
+			if err := expression.Validate(); err != nil {
+				return nil, fmt.Errorf("Could not resolve the value of parameter %s for environment variable %s in container %s: %w", env.FromParam, env.Name, container.Name, err)
+			}
+			switch expression.Function {
+			case workload.ImportValueFunction:
+				environment = append(environment, containerEnvVar{Name: env.Name, ImportValue: expression.Args[0]})
+			case workload.DiscoveryHostnameFunction:
+				environment = append(environment, containerEnvVar{Name: env.Name, DiscoveryInstance: expression.Args[0]})
+			case workload.ComponentOutputFunction:
+				// References to component outputs are resolved before rendering, except in dry runs where they are left as written
+				environment = append(environment, containerEnvVar{Name: env.Name, Value: value})
+			}
+			continue
+		}
+		environment = append(environment, containerEnvVar{Name: env.Name, Value: value})
+	}
+
+	// The tracing trait points the X-Ray SDKs to the daemon, unless the container sets the address itself
+	tracing, err := resolveTracing(componentConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	if tracing != nil {
+		for _, env := range container.Env {
+			if env.Name == workload.XRayDaemonAddressEnv {
+				return environment, nil
+			}
+		}
+		environment = append(environment, containerEnvVar{Name: workload.XRayDaemonAddressEnv, Value: tracing.DaemonAddress})
+	}
+
+	return environment, nil
+}
+
+// resolveContainerSecrets lists the environment variables for a container that are injected
+// from Secrets Manager or SSM Parameter Store
+func resolveContainerSecrets(container v1alpha1.Container, componentConfiguration *v1alpha1.ComponentConfiguration, componentSpec *v1alpha1.ComponentSpec) ([]containerSecret, error) {
+	secrets := []containerSecret{}
+
+	for _, env := range container.Env {
+		value, err := resolveEnvValue(env, componentConfiguration, componentSpec)
+		if err != nil {
+			return nil, err
+		}
+		if !isSecretReference(value) {
+			continue
+		}
+
+		valueFrom, err := secretValueFrom(value)
+		if err != nil {
+			return nil, fmt.Errorf("Could not resolve the secret for environment variable %s in container %s: %w", env.Name, container.Name, err)
+		}
+		secrets = append(secrets, containerSecret{Name: env.Name, ValueFrom: valueFrom})
+	}
+
+	return secrets, nil
+}
+
+// resolveSidecarEnvironment lists the plain text environment variables for a sidecar
+func resolveSidecarEnvironment(sidecar *workload.Sidecar) []containerEnvVar {
+	environment := []containerEnvVar{}
+	for _, env := range sidecar.Environment {
+		if !isSecretReference(env.Value) {
+			environment = append(environment, containerEnvVar{Name: env.Name, Value: env.Value})
+		}
+	}
+	return environment
+}
+
+// resolveSidecarSecrets lists the environment variables for a sidecar that are injected
+// from Secrets Manager or SSM Parameter Store
+func resolveSidecarSecrets(sidecar *workload.Sidecar) ([]containerSecret, error) {
+	secrets := []containerSecret{}
+	for _, env := range sidecar.Environment {
+		if !isSecretReference(env.Value) {
+			continue
+		}
+
+		valueFrom, err := secretValueFrom(env.Value)
+		if err != nil {
+			return nil, fmt.Errorf("Could not resolve the secret for environment variable %s in sidecar %s: %w", env.Name, sidecar.Name, err)
+		}
+		secrets = append(secrets, containerSecret{Name: env.Name, ValueFrom: valueFrom})
+	}
+	return secrets, nil
+}
+
+// resolveExecutionRoleSecrets finds the Secrets Manager secrets and SSM parameters referenced
+// by the environment variables of the containers and the sidecars, so that the execution role can be granted access
+// to exactly those
+func resolveExecutionRoleSecrets(containers []v1alpha1.Container, sidecars []*workload.Sidecar, componentConfiguration *v1alpha1.ComponentConfiguration, componentSpec *v1alpha1.ComponentSpec) (*executionRoleSecrets, error) {
+	resources := &executionRoleSecrets{}
+	seen := make(map[string]bool)
+
+	add := func(value string, valueFrom string) {
+		if strings.HasPrefix(value, secretsManagerSourcePrefix) {
+			resource := secretsManagerResource(strings.TrimPrefix(value, secretsManagerSourcePrefix))
+			if !seen[resource] {
+				seen[resource] = true
+				resources.SecretsManager = append(resources.SecretsManager, resource)
+			}
+		} else if !seen[valueFrom] {
+			seen[valueFrom] = true
+			resources.SSM = append(resources.SSM, valueFrom)
+		}
+	}
+
+	for _, container := range containers {
+		for _, env := range container.Env {
+			value, err := resolveEnvValue(env, componentConfiguration, componentSpec)
+			if err != nil {
+				return nil, err
+			}
+			if !isSecretReference(value) {
+				continue
+			}
+
+			valueFrom, err := secretValueFrom(value)
+			if err != nil {
+				return nil, fmt.Errorf("Could not resolve the secret for environment variable %s in container %s: %w", env.Name, container.Name, err)
+			}
+			add(value, valueFrom)
+		}
+	}
+
+	for _, sidecar := range sidecars {
+		for _, env := range sidecar.Environment {
+			if !isSecretReference(env.Value) {
+				continue
+			}
+
+			valueFrom, err := secretValueFrom(env.Value)
+			if err != nil {
+				return nil, fmt.Errorf("Could not resolve the secret for environment variable %s in sidecar %s: %w", env.Name, sidecar.Name, err)
+			}
+			add(env.Value, valueFrom)
+		}
+	}
+
+	configFiles, err := resolveConfigFiles(containers, componentConfiguration, componentSpec)
+	if err != nil {
+		return nil, err
+	}
+	for _, configFile := range configFiles {
+		resources.ConfigFiles = append(resources.ConfigFiles, configFile.ParameterARN)
+	}
+
+	return resources, nil
+}
+
+// secretValueFrom translates a secret reference like "secretsmanager:db-password" or "ssm:/prod/db/password"
+// into the ARN given to the container definition. Secret names and parameter names are turned into ARNs
+// in the stack's account and region, while ARNs are used as is.
+func secretValueFrom(reference string) (string, error) {
+	if strings.HasPrefix(reference, secretsManagerSourcePrefix) {
+		secret := strings.TrimPrefix(reference, secretsManagerSourcePrefix)
+		if secret == "" {
+			return "", fmt.Errorf("Secret reference %s does not include a secret name or ARN", reference)
+		}
+		if strings.HasPrefix(secret, "arn:") {
+			return secret, nil
+		}
+		return fmt.Sprintf(secretsManagerARNFormat, secret), nil
+	}
+
+	parameter := strings.TrimPrefix(reference, ssmSourcePrefix)
+	if parameter == "" {
+		return "", fmt.Errorf("Secret reference %s does not include a parameter name or ARN", reference)
+	}
+	if strings.HasPrefix(parameter, "arn:") {
+		return parameter, nil
+	}
+	return fmt.Sprintf(ssmParameterARNFormat, strings.TrimPrefix(parameter, "/")), nil
+}
+
+// secretsManagerResource returns the IAM policy resource for a Secrets Manager secret name or ARN.
+// Secrets Manager appends six random characters to the ARN of a secret, which are matched for secret names.
+// JSON key, version stage and version ID suffixes of secret ARNs are ignored.
+func secretsManagerResource(secret string) string {
+	if strings.HasPrefix(secret, "arn:") {
+		// arn:partition:secretsmanager:region:account:secret:name-suffix[:json-key:version-stage:version-id]
+		parts := strings.SplitN(secret, ":", 8)
+		if len(parts) > 7 {
+			parts = parts[:7]
+		}
+		return strings.Join(parts, ":")
+	}
+	return fmt.Sprintf(secretsManagerARNFormat, secret) + "-??????"
+}
+
+const (
+	// configInitContainerName is the name of the generated container that writes the config files
+	// into the task's config volumes before the other containers start
+	configInitContainerName = "config-init"
+	configInitMountPath     = "/config"
+
+	configFileEnvVarFormat       = "CONFIG_FILE_%d"
+	configFileResourceFormat     = "ConfigFile%d"
+	configFileParameterARNFormat = "arn:${AWS::Partition}:ssm:${AWS::Region}:${AWS::AccountId}:parameter${ConfigFile%d}"
+
+	// SSM parameter values are limited to 4 KB in the standard tier and 8 KB in the advanced tier
+	ssmStandardParameterMaxLength = 4096
+	ssmAdvancedParameterMaxLength = 8192
+)
+
+type configFile struct {
+	Index         int
+	ContainerName string
+	// Path is the location of the file in the container that declares it
+	Path string
+	// InitPath is the location of the file in the config init container
+	InitPath     string
+	EnvName      string
+	Resource     string
+	ParameterARN string
+	// Content is the URL-safe base64 encoded file content stored in SSM Parameter Store
+	Content string
+	Tier    string
+}
+
+type configVolume struct {
+	Name          string
+	ContainerName string
+	MountPath     string
+}
+
+type containerDependency struct {
+	ContainerName string
+	Condition     string
+}
+
+// resolveConfigVolumes lists the task volumes needed to share config files between the config init container
+// and the containers declaring them, with one volume for each directory that contains config files
+func resolveConfigVolumes(containers []v1alpha1.Container) []configVolume {
+	volumes := []configVolume{}
+
+	for _, container := range containers {
+		directories := make(map[string]bool)
+		for _, config := range container.Config {
+			directory := path.Dir(config.Path)
+			if directories[directory] {
+				continue
+			}
+			directories[directory] = true
+			volumes = append(volumes, configVolume{
+				Name:          fmt.Sprintf("%s-config-%d", container.Name, len(directories)),
+				ContainerName: container.Name,
+				MountPath:     directory,
+			})
+		}
+	}
+
+	return volumes
+}
+
+// resolveConfigFilesHash hashes the paths and contents of the config files. The config init container reads the
+// files from SSM Parameter Store by name, so the hash changes the task definition when the files change.
+func resolveConfigFilesHash(files []configFile) string {
+	hash := sha256.New()
+	for _, file := range files {
+		fmt.Fprintf(hash, "%s\x00%s\x00%s\x00", file.ContainerName, file.Path, file.Content)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// resolveConfigFiles renders the containers' config files, so that they can be stored in SSM Parameter Store
+// and written to the config volumes by the config init container
+func resolveConfigFiles(containers []v1alpha1.Container, componentConfiguration *v1alpha1.ComponentConfiguration, componentSpec *v1alpha1.ComponentSpec) ([]configFile, error) {
+	files := []configFile{}
+	volumes := resolveConfigVolumes(containers)
 
 	for _, container := range containers {
-		if container.LivenessProbe != nil && (container.LivenessProbe.HttpGet != nil || container.LivenessProbe.TcpSocket != nil) {
-			if container.LivenessProbe.InitialDelaySeconds > gracePeriod {
-				gracePeriod = container.LivenessProbe.InitialDelaySeconds
+		paths := make(map[string]bool)
+
+		for _, config := range container.Config {
+			if problem := workload.ConfigFilePathProblem(config.Path); problem != "" {
+				return nil, fmt.Errorf("%s in container %s", problem, container.Name)
+			}
+			if paths[config.Path] {
+				return nil, fmt.Errorf("Config file path %s is declared more than once in container %s", config.Path, container.Name)
+			}
+			paths[config.Path] = true
+			if container.Name == configInitContainerName {
+				return nil, fmt.Errorf("Container name %s is reserved for writing config files", configInitContainerName)
+			}
+
+			value := config.Value
+			if config.FromParam != "" {
+				paramValue, err := resolveOAMParameterValue(config.FromParam, componentConfiguration, componentSpec)
+				if err != nil {
+					return nil, err
+				}
+				// References to component outputs are resolved before rendering, except in dry runs where they are left as written
+				if expression, ok := workload.ParseParameterExpression(paramValue); ok && expression.Function != workload.ComponentOutputFunction {
+					return nil, fmt.Errorf("Config file %s in container %s refers to parameter %s, whose value is an expression, which can only be used in environment variables", config.Path, container.Name, config.FromParam)
+				}
+				value = paramValue
+			}
+
+			if value == "" {
+				return nil, fmt.Errorf("Config file %s in container %s is empty", config.Path, container.Name)
+			}
+
+			content := base64.URLEncoding.EncodeToString([]byte(value))
+			tier := "Standard"
+			if len(content) > ssmAdvancedParameterMaxLength {
+				return nil, fmt.Errorf("Config file %s in container %s is too large, it can be at most %d bytes when encoded", config.Path, container.Name, ssmAdvancedParameterMaxLength)
+			}
+			if len(content) > ssmStandardParameterMaxLength {
+				tier = "Advanced"
+			}
+
+			var volumeName string
+			for _, volume := range volumes {
+				if volume.ContainerName == container.Name && volume.MountPath == path.Dir(config.Path) {
+					volumeName = volume.Name
+					break
+				}
+			}
+
+			index := len(files)
+			files = append(files, configFile{
+				Index:         index,
+				ContainerName: container.Name,
+				Path:          config.Path,
+				InitPath:      path.Join(configInitMountPath, volumeName, path.Base(config.Path)),
+				EnvName:       fmt.Sprintf(configFileEnvVarFormat, index),
+				Resource:      fmt.Sprintf(configFileResourceFormat, index),
+				ParameterARN:  fmt.Sprintf(configFileParameterARNFormat, index),
+				Content:       content,
+				Tier:          tier,
+			})
+		}
+	}
+
+	return files, nil
+}
+
+// resolveContainerDependencies lists the containers that must reach a given condition
+// before a container can start. A container waits for its sidecars to start, or to become healthy for sidecars like
+// proxies, for its config files to be written and its health check helper to be installed, and for the containers
+// declared before it that have a readiness probe to become ready.
+func resolveContainerDependencies(container v1alpha1.Container, componentSpec *v1alpha1.ComponentSpec, sidecars []*workload.Sidecar) []containerDependency {
+	dependencies := []containerDependency{}
+
+	for _, sidecar := range sidecars {
+		dependencies = append(dependencies, containerDependency{
+			ContainerName: sidecar.Name,
+			Condition:     sidecar.Condition,
+		})
+	}
+
+	if len(container.Config) > 0 {
+		dependencies = append(dependencies, containerDependency{
+			ContainerName: configInitContainerName,
+			Condition:     "SUCCESS",
+		})
+	}
+
+	if usesHealthCheckHelper(container, componentSpec.WorkloadType) {
+		dependencies = append(dependencies, containerDependency{
+			ContainerName: healthCheckHelperContainerName,
+			Condition:     "SUCCESS",
+		})
+	}
+
+	for _, other := range componentSpec.Containers {
+		if other.Name == container.Name {
+			break
+		}
+		if other.ReadinessProbe == nil {
+			continue
+		}
+
+		// ECS can only tell whether a container is ready if its readiness probe translates to the container
+		// health check, otherwise the best it can do is wait for the container to start
+		condition := "START"
+		if workload.ContainerHealthCheckProbe(componentSpec.WorkloadType, other) == other.ReadinessProbe {
+			condition = "HEALTHY"
+		}
+		dependencies = append(dependencies, containerDependency{
+			ContainerName: other.Name,
+			Condition:     condition,
+		})
+	}
+
+	return dependencies
+}
+
+const (
+	// healthCheckHelperContainerName is the name of the generated container that copies the health check helper
+	// into the containers whose httpGet or tcpSocket probes translate to ECS container health checks. The helper
+	// is the statically linked busybox binary of a published image, pinned by version, whose wget and nc applets
+	// run the probes, since container images cannot be expected to include curl or wget.
+	healthCheckHelperContainerName = "healthcheck-init"
+	healthCheckHelperPath          = "/oam-ecs/busybox"
+)
+
+type containerHealthCheck struct {
+	Command     []string
+	Interval    int32
+	Retries     int32
+	StartPeriod int32
+	Timeout     int32
+	// UsesHelper is set when the command runs the health check helper, rather than a command from the container image
+	UsesHelper bool
+}
+
+// resolveContainerHealthCheck translates a container's probe to an ECS container health check.
+// ECS health checks run a command in the container, so httpGet and tcpSocket probes run the health check helper.
+// Probe settings outside the limits of ECS container health checks are an error.
+func resolveContainerHealthCheck(container v1alpha1.Container, workloadType string) (*containerHealthCheck, error) {
+	probe := workload.ContainerHealthCheckProbe(workloadType, container)
+	if probe == nil {
+		return nil, nil
+	}
+	if problems := workload.ContainerHealthCheckProblems(probe); len(problems) > 0 {
+		names := make([]string, 0, len(problems))
+		for name := range problems {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("Invalid property %s of the health check probe of container %s: %s", names[0], container.Name, problems[names[0]])
+	}
+
+	healthCheck := &containerHealthCheck{
+		Interval:    10,
+		Retries:     3,
+		StartPeriod: probe.InitialDelaySeconds,
+		Timeout:     2,
+	}
+	if probe.PeriodSeconds > 0 {
+		healthCheck.Interval = probe.PeriodSeconds
+	}
+	if probe.FailureThreshold > 0 {
+		healthCheck.Retries = probe.FailureThreshold
+	}
+	if probe.TimeoutSeconds > 0 {
+		healthCheck.Timeout = probe.TimeoutSeconds
+	}
+
+	timeout := fmt.Sprintf("%d", healthCheck.Timeout)
+	switch {
+	case probe.Exec != nil:
+		healthCheck.Command = probe.Exec.Command
+	case probe.HttpGet != nil:
+		healthCheck.UsesHelper = true
+		healthCheck.Command = []string{"CMD", healthCheckHelperPath, "wget", "-q", "-O", "/dev/null", "-T", timeout}
+		for _, header := range probe.HttpGet.HttpHeaders {
+			healthCheck.Command = append(healthCheck.Command, "--header", fmt.Sprintf("%s: %s", header.Name, header.Value))
+		}
+		healthCheck.Command = append(healthCheck.Command, fmt.Sprintf("http://127.0.0.1:%d%s", probe.HttpGet.Port, httpProbePath(probe.HttpGet.Path)))
+	default:
+		healthCheck.UsesHelper = true
+		healthCheck.Command = []string{"CMD", healthCheckHelperPath, "nc", "-z", "-w", timeout,
+			"127.0.0.1", fmt.Sprintf("%d", probe.TcpSocket.Port)}
+	}
+
+	return healthCheck, nil
+}
+
+// usesHealthCheckHelper checks whether a container's health check runs the health check helper, which is the case
+// for the httpGet and tcpSocket probes
+func usesHealthCheckHelper(container v1alpha1.Container, workloadType string) bool {
+	probe := workload.ContainerHealthCheckProbe(workloadType, container)
+	return probe != nil && probe.Exec == nil
+}
+
+// httpProbePath returns the path of an httpGet probe, which defaults to the root path
+func httpProbePath(probePath string) string {
+	if !strings.HasPrefix(probePath, "/") {
+		return "/" + probePath
+	}
+	return probePath
+}
+
+// requiresHealthCheckHelper checks whether at least one of the containers' health checks runs the health check helper
+func requiresHealthCheckHelper(componentSpec *v1alpha1.ComponentSpec) bool {
+	for _, container := range componentSpec.Containers {
+		if usesHealthCheckHelper(container, componentSpec.WorkloadType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolveTargetGroupHealthCheck finds the probe that translates to the load balancer target group health check
+func resolveTargetGroupHealthCheck(container v1alpha1.Container, workloadType string) *v1alpha1.HealthProbe {
+	return workload.TargetGroupProbe(workloadType, container)
+}
+
+// resolveHealthCheckGracePeriod finds the max grace period across the target group health checks of all containers
+func resolveHealthCheckGracePeriod(componentSpec *v1alpha1.ComponentSpec) int32 {
+	gracePeriod := int32(0)
+
+	for _, container := range componentSpec.Containers {
+		if probe := resolveTargetGroupHealthCheck(container, componentSpec.WorkloadType); probe != nil {
+			if probe.InitialDelaySeconds > gracePeriod {
+				gracePeriod = probe.InitialDelaySeconds
 			}
 		}
 	}
@@ -132,7 +1239,7 @@ func getValidFargateTaskSizes() []*fargateTaskSize {
 	return taskSizes
 }
 
-func getNearestFargateTaskSize(containers []v1alpha1.Container) (*fargateTaskSize, error) {
+func getNearestFargateTaskSize(containers []v1alpha1.Container, sidecars []*workload.Sidecar) (*fargateTaskSize, error) {
 	containersCpuShare := float64(0)
 	containersMemoryMiB := int64(0)
 
@@ -140,6 +1247,10 @@ func getNearestFargateTaskSize(containers []v1alpha1.Container) (*fargateTaskSiz
 		containersCpuShare += float64(container.Resources.Cpu.Required.MilliValue()) / float64(1000)
 		containersMemoryMiB += container.Resources.Memory.Required.MilliValue() / int64(1000) / int64(1000000)
 	}
+	for _, sidecar := range sidecars {
+		containersCpuShare += sidecar.CPU
+		containersMemoryMiB += sidecar.MemoryMiB
+	}
 
 	for _, taskSize := range getValidFargateTaskSizes() {
 		if containersCpuShare <= taskSize.cpuShare && containersMemoryMiB <= taskSize.memoryMiB {
@@ -150,22 +1261,55 @@ func getNearestFargateTaskSize(containers []v1alpha1.Container) (*fargateTaskSiz
 	return nil, fmt.Errorf("Could not find valid Fargate task size for the given CPU and memory requirements: %f CPU shares, %d MiB memory", containersCpuShare, containersMemoryMiB)
 }
 
-// resolveTaskCpuValue finds the closest Fargate size for the containers' CPU and memory requirements,
-// and returns the Fargate CPU size
-func resolveTaskCpuValue(containers []v1alpha1.Container) (string, error) {
-	taskSize, err := getNearestFargateTaskSize(containers)
+// resolveTaskCpuValue finds the closest Fargate size for the CPU and memory requirements of the containers and
+// the sidecars, and returns the Fargate CPU size
+func resolveTaskCpuValue(containers []v1alpha1.Container, sidecars []*workload.Sidecar) (string, error) {
+	taskSize, err := getNearestFargateTaskSize(containers, sidecars)
 	if err != nil {
 		return "", err
 	}
 	return fmt.Sprintf("%.2f vcpu", taskSize.cpuShare), nil
 }
 
-// resolveTaskMemoryValue finds the closest Fargate size for the containers' CPU and memory requirements,
-// and returns the Fargate memory size
-func resolveTaskMemoryValue(containers []v1alpha1.Container) (string, error) {
-	taskSize, err := getNearestFargateTaskSize(containers)
+// resolveTaskMemoryValue finds the closest Fargate size for the CPU and memory requirements of the containers and
+// the sidecars, and returns the Fargate memory size
+func resolveTaskMemoryValue(containers []v1alpha1.Container, sidecars []*workload.Sidecar) (string, error) {
+	taskSize, err := getNearestFargateTaskSize(containers, sidecars)
 	if err != nil {
 		return "", err
 	}
 	return fmt.Sprintf("%d", taskSize.memoryMiB), nil
 }
+
+const (
+	// Fargate tasks receive 20 GiB of ephemeral storage by default, and can be configured with up to 200 GiB
+	fargateDefaultEphemeralStorageGiB = 20
+	fargateMaxEphemeralStorageGiB     = 200
+)
+
+// resolveTaskEphemeralStorageValue sums the disk requirements of the containers' ephemeral volumes
+// and returns the Fargate ephemeral storage size in GiB, or 0 if the default ephemeral storage is sufficient
+func resolveTaskEphemeralStorageValue(containers []v1alpha1.Container) (int64, error) {
+	totalBytes := int64(0)
+
+	for _, volume := range uniqueVolumes(containers) {
+		if isPersistentVolume(volume) || volume.Disk == nil || volume.Disk.Required == "" {
+			continue
+		}
+
+		required, err := resource.ParseQuantity(volume.Disk.Required)
+		if err != nil {
+			return 0, fmt.Errorf("Could not parse the disk requirement %s for volume %s: %w", volume.Disk.Required, volume.Name, err)
+		}
+		totalBytes += required.Value()
+	}
+
+	totalGiB := int64(math.Ceil(float64(totalBytes) / float64(1<<30)))
+	if totalGiB <= fargateDefaultEphemeralStorageGiB {
+		return 0, nil
+	}
+	if totalGiB > fargateMaxEphemeralStorageGiB {
+		return 0, fmt.Errorf("Could not find valid Fargate ephemeral storage size for the given disk requirements: %d GiB, the maximum is %d GiB", totalGiB, fargateMaxEphemeralStorageGiB)
+	}
+	return totalGiB, nil
+}
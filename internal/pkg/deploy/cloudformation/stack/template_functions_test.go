@@ -0,0 +1,211 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package stack
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/oam-dev/oam-go-sdk/apis/core.oam.dev/v1alpha1"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	serverWorkloadType = "core.oam.dev/v1alpha1.Server"
+	workerWorkloadType = "core.oam.dev/v1alpha1.Worker"
+)
+
+func TestResolveContainerHealthCheck(t *testing.T) {
+	tests := map[string]struct {
+		workloadType string
+		probe        *v1alpha1.HealthProbe
+
+		want    *containerHealthCheck
+		wantErr string
+	}{
+		"exec probe runs its command": {
+			workloadType: serverWorkloadType,
+			probe: &v1alpha1.HealthProbe{
+				Exec:                &v1alpha1.Exec{Command: []string{"CMD-SHELL", "pgrep app"}},
+				InitialDelaySeconds: 10,
+				PeriodSeconds:       30,
+			},
+			want: &containerHealthCheck{
+				Command:     []string{"CMD-SHELL", "pgrep app"},
+				Interval:    30,
+				Retries:     3,
+				StartPeriod: 10,
+				Timeout:     2,
+			},
+		},
+		"worker httpGet probe runs busybox wget": {
+			workloadType: workerWorkloadType,
+			probe: &v1alpha1.HealthProbe{
+				HttpGet: &v1alpha1.HttpGet{
+					Port:        8080,
+					Path:        "healthz",
+					HttpHeaders: []v1alpha1.HttpHeader{{Name: "X-Probe", Value: "liveness"}},
+				},
+				TimeoutSeconds:   5,
+				FailureThreshold: 4,
+			},
+			want: &containerHealthCheck{
+				Command: []string{"CMD", "/oam-ecs/busybox", "wget", "-q", "-O", "/dev/null", "-T", "5",
+					"--header", "X-Probe: liveness", "http://127.0.0.1:8080/healthz"},
+				Interval:   10,
+				Retries:    4,
+				Timeout:    5,
+				UsesHelper: true,
+			},
+		},
+		"worker tcpSocket probe runs busybox nc": {
+			workloadType: workerWorkloadType,
+			probe:        &v1alpha1.HealthProbe{TcpSocket: &v1alpha1.TcpSocket{Port: 5672}},
+			want: &containerHealthCheck{
+				Command:    []string{"CMD", "/oam-ecs/busybox", "nc", "-z", "-w", "2", "127.0.0.1", "5672"},
+				Interval:   10,
+				Retries:    3,
+				Timeout:    2,
+				UsesHelper: true,
+			},
+		},
+		"server httpGet liveness probe of a container without ports runs busybox wget": {
+			workloadType: serverWorkloadType,
+			probe:        &v1alpha1.HealthProbe{HttpGet: &v1alpha1.HttpGet{Port: 9100, Path: "/live"}},
+			want: &containerHealthCheck{
+				Command:    []string{"CMD", "/oam-ecs/busybox", "wget", "-q", "-O", "/dev/null", "-T", "2", "http://127.0.0.1:9100/live"},
+				Interval:   10,
+				Retries:    3,
+				Timeout:    2,
+				UsesHelper: true,
+			},
+		},
+		"probe settings outside the ECS limits": {
+			workloadType: workerWorkloadType,
+			probe: &v1alpha1.HealthProbe{
+				Exec:           &v1alpha1.Exec{Command: []string{"CMD-SHELL", "pgrep app"}},
+				PeriodSeconds:  1,
+				TimeoutSeconds: 1,
+			},
+			wantErr: "Invalid property periodSeconds of the health check probe of container app: ECS container health checks run every 5 to 300 seconds, got 1",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			container := v1alpha1.Container{Name: "app", LivenessProbe: tc.probe}
+			healthCheck, err := resolveContainerHealthCheck(container, tc.workloadType)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, healthCheck)
+		})
+	}
+}
+
+func TestRequiresHealthCheckHelper(t *testing.T) {
+	container := v1alpha1.Container{
+		Name:          "app",
+		Ports:         []v1alpha1.Port{{Name: "http", ContainerPort: 8080}},
+		LivenessProbe: &v1alpha1.HealthProbe{HttpGet: &v1alpha1.HttpGet{Port: 8080, Path: "/healthz"}},
+	}
+	require.True(t, requiresHealthCheckHelper(&v1alpha1.ComponentSpec{WorkloadType: workerWorkloadType, Containers: []v1alpha1.Container{container}}))
+
+	// The liveness probe of a Server container is the target group health check, unless the readiness probe is
+	container.ReadinessProbe = &v1alpha1.HealthProbe{HttpGet: &v1alpha1.HttpGet{Port: 8080, Path: "/ready"}}
+	require.True(t, requiresHealthCheckHelper(&v1alpha1.ComponentSpec{WorkloadType: serverWorkloadType, Containers: []v1alpha1.Container{container}}))
+	container.ReadinessProbe = nil
+	require.False(t, requiresHealthCheckHelper(&v1alpha1.ComponentSpec{WorkloadType: serverWorkloadType, Containers: []v1alpha1.Container{container}}))
+}
+
+func TestResolveEndpointProtocol(t *testing.T) {
+	tests := map[string]struct {
+		port  v1alpha1.Port
+		probe *v1alpha1.HealthProbe
+
+		want string
+	}{
+		"unnamed port without probe is tcp": {
+			port: v1alpha1.Port{Name: "port", ContainerPort: 8080},
+			want: "tcp",
+		},
+		"udp port": {
+			port: v1alpha1.Port{Name: "http", ContainerPort: 7777, Protocol: "UDP"},
+			want: "udp",
+		},
+		"port named http": {
+			port: v1alpha1.Port{Name: "http", ContainerPort: 8080},
+			want: "http",
+		},
+		"port named with an http- prefix": {
+			port: v1alpha1.Port{Name: "http-admin", ContainerPort: 9090},
+			want: "http",
+		},
+		"port with an httpGet probe": {
+			port:  v1alpha1.Port{Name: "web", ContainerPort: 8080},
+			probe: &v1alpha1.HealthProbe{HttpGet: &v1alpha1.HttpGet{Path: "/health", Port: 8080}},
+			want:  "http",
+		},
+		"port with an httpGet probe on another port": {
+			port:  v1alpha1.Port{Name: "grpc", ContainerPort: 9000},
+			probe: &v1alpha1.HealthProbe{HttpGet: &v1alpha1.HttpGet{Path: "/health", Port: 8080}},
+			want:  "tcp",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			container := v1alpha1.Container{Name: "app", Ports: []v1alpha1.Port{tc.port}, ReadinessProbe: tc.probe}
+
+			require.Equal(t, tc.want, resolveEndpointProtocol(container, tc.port))
+		})
+	}
+}
+
+func TestPolicyString(t *testing.T) {
+	require.Equal(t, template.HTML(`'*'`), policyString("*"))
+	require.Equal(t, template.HTML(`'arn:aws:s3:::reports/${aws:username}/*'`), policyString("arn:aws:s3:::reports/${aws:username}/*"))
+	require.Equal(t, template.HTML(`!Sub 'arn:${AWS::Partition}:s3:::reports/${!aws:username}/*'`), policyString("arn:${AWS::Partition}:s3:::reports/${aws:username}/*"))
+	require.Equal(t, template.HTML(`'arn:aws:s3:::o''brien/*'`), policyString("arn:aws:s3:::o'brien/*"))
+	require.Equal(t, template.HTML(`!Sub 'arn:${AWS::Partition}:s3:::o''brien/*'`), policyString("arn:${AWS::Partition}:s3:::o'brien/*"))
+}
+
+func TestResolveDiscoveryIngressRules(t *testing.T) {
+	application := &v1alpha1.ApplicationConfiguration{}
+	application.Spec.Components = []v1alpha1.ComponentConfiguration{
+		{ComponentName: "api", InstanceName: "search-api", ParameterValues: []v1alpha1.ParameterValue{{Name: "queue", Value: "[discoveryHostname(queue)]"}}},
+		{ComponentName: "worker", InstanceName: "queue", ParameterValues: []v1alpha1.ParameterValue{{Name: "api", Value: "[discoveryHostname(search-api)]"}}},
+	}
+	schematics := map[string]*v1alpha1.ComponentSchematic{
+		"api": {Spec: v1alpha1.ComponentSpec{
+			WorkloadType: serverWorkloadType,
+			Containers: []v1alpha1.Container{
+				{Name: "api", Ports: []v1alpha1.Port{{Name: "http", ContainerPort: 8080}, {Name: "metrics", ContainerPort: 9125, Protocol: "UDP"}}},
+			},
+		}},
+		"worker": {Spec: v1alpha1.ComponentSpec{
+			WorkloadType: workerWorkloadType,
+			Containers:   []v1alpha1.Container{{Name: "worker"}},
+		}},
+	}
+
+	// The rules belong to the component instance deployed last, and Worker components accept no traffic
+	require.Empty(t, resolveDiscoveryIngressRules(application, "search-api", schematics))
+	require.Equal(t, []discoveryIngressRule{
+		{ingressRule: ingressRule{Protocol: "tcp", Port: 8080}, Resource: "DiscoveryIngressQueueToSearchApiTCP8080", Client: "queue", Server: "search-api"},
+		{ingressRule: ingressRule{Protocol: "udp", Port: 9125}, Resource: "DiscoveryIngressQueueToSearchApiUDP9125", Client: "queue", Server: "search-api"},
+	}, resolveDiscoveryIngressRules(application, "queue", schematics))
+}
+
+func TestResolveConfigFilesHash(t *testing.T) {
+	files := []configFile{{ContainerName: "web", Path: "/etc/web/app.conf", Content: "YQ=="}}
+	changed := []configFile{{ContainerName: "web", Path: "/etc/web/app.conf", Content: "Yg=="}}
+	moved := []configFile{{ContainerName: "web", Path: "/etc/web/web.conf", Content: "YQ=="}}
+
+	require.Len(t, resolveConfigFilesHash(files), 64)
+	require.Equal(t, resolveConfigFilesHash(files), resolveConfigFilesHash(files))
+	require.NotEqual(t, resolveConfigFilesHash(files), resolveConfigFilesHash(changed))
+	require.NotEqual(t, resolveConfigFilesHash(files), resolveConfigFilesHash(moved))
+}
@@ -22,3 +22,8 @@ func (s StackStatus) RequiresCleanup() bool {
 func (s StackStatus) InProgress() bool {
 	return strings.HasSuffix(string(s), "IN_PROGRESS")
 }
+
+// Failed indicates that the last create, update or delete of the stack failed or was rolled back.
+func (s StackStatus) Failed() bool {
+	return strings.HasSuffix(string(s), "FAILED") || strings.Contains(string(s), "ROLLBACK")
+}
@@ -0,0 +1,63 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package types
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// Aggregate statuses of the component instance stacks of an application
+const (
+	ApplicationStatusComplete   = "COMPLETE"
+	ApplicationStatusInProgress = "IN_PROGRESS"
+	ApplicationStatusFailed     = "FAILED"
+)
+
+// Application represents the component instances of an application deployed in an environment
+type Application struct {
+	Name         string                  `json:"name" yaml:"name"`
+	Environment  string                  `json:"environment" yaml:"environment"`
+	Status       string                  `json:"status" yaml:"status"`
+	LastDeployed time.Time               `json:"lastDeployed" yaml:"lastDeployed"`
+	Components   []*ApplicationComponent `json:"components" yaml:"components"`
+}
+
+// ApplicationComponent represents the stack of a component instance of a deployed application
+type ApplicationComponent struct {
+	InstanceName string    `json:"instanceName" yaml:"instanceName"`
+	StackName    string    `json:"stackName" yaml:"stackName"`
+	StackStatus  string    `json:"status" yaml:"status"`
+	LastUpdated  time.Time `json:"lastUpdated" yaml:"lastUpdated"`
+}
+
+// DisplayApplications writes the deployed applications to w as a table, one row per application and environment
+func DisplayApplications(w io.Writer, applications []*Application) {
+	if len(applications) == 0 {
+		fmt.Fprintln(w, "No applications found.")
+		return
+	}
+
+	fmt.Fprintln(w, "")
+
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Application", "Environment", "Components", "Status", "Last Deployed"})
+	table.SetBorder(false)
+
+	for _, application := range applications {
+		table.Append([]string{
+			application.Name,
+			application.Environment,
+			strconv.Itoa(len(application.Components)),
+			application.Status,
+			application.LastDeployed.Format(time.RFC3339),
+		})
+	}
+
+	table.Render()
+	fmt.Fprintln(w, "")
+}
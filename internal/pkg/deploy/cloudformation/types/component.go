@@ -4,13 +4,30 @@ package types
 
 import (
 	"fmt"
-	"os"
+	"io"
 	"sort"
 	"strings"
+	"time"
 
-	"github.com/iancoleman/strcase"
 	"github.com/oam-dev/oam-go-sdk/apis/core.oam.dev/v1alpha1"
-	"github.com/olekukonko/tablewriter"
+)
+
+const (
+	// LogStreamPrefix is the awslogs-stream-prefix of the component instance containers, the log streams
+	// are named <prefix>/<container>/<task>
+	LogStreamPrefix = "oam-ecs"
+
+	// endpointOutputSuffix ends the keys of the stack outputs that hold a load balancer endpoint, as host:port
+	endpointOutputSuffix = "Endpoint"
+	// protocolOutputSuffix ends the keys of the stack outputs that hold the protocol of the endpoint output with the
+	// same key prefix: http, tcp or udp
+	protocolOutputSuffix = "Protocol"
+	// httpProtocol is the endpoint protocol of the ports that serve HTTP
+	httpProtocol = "http"
+	// defaultEndpointProtocol is the protocol of the endpoints of stacks deployed without protocol outputs
+	defaultEndpointProtocol = "tcp"
+	// alarmOutputSuffix ends the keys of the stack outputs that hold the ARN of a CloudWatch alarm
+	alarmOutputSuffix = "AlarmArn"
 )
 
 // ComponentInput holds the fields required to deploy an component instance.
@@ -20,6 +37,14 @@ type ComponentInput struct {
 	Component                *v1alpha1.ComponentSchematic
 	Environment              *ComponentEnvironment
 	WorkloadSettings         *ECSWorkloadSettings
+	// ComponentSchematics are the schematics of the application's components by name, to render the security group
+	// rules between the component instance and the component instances it is related to
+	ComponentSchematics map[string]*v1alpha1.ComponentSchematic
+}
+
+// LogGroupName returns the name of the CloudWatch Logs log group of the component instance's containers
+func (input *ComponentInput) LogGroupName() string {
+	return fmt.Sprintf("%s-%s-%s", input.Environment.Name, input.ApplicationConfiguration.Name, input.ComponentConfiguration.InstanceName)
 }
 
 // ECSWorkloadSettings holds fields that are needed to define services in ECS, which are not part of the core OAM types
@@ -31,12 +56,28 @@ type ECSWorkloadSettings struct {
 // Environment represents attributes about the environment where the component will be deployed
 type ComponentEnvironment struct {
 	Name string
+	// Logs are the environment's defaults for the log groups of the component instances, nil if unknown
+	Logs *LogSettings
 }
 
 // Component represents the configuration of a particular component instance
 type Component struct {
-	StackName    string
-	StackOutputs map[string]string
+	StackName    string            `json:"stackName" yaml:"stackName"`
+	StackStatus  string            `json:"status" yaml:"status"`
+	LastUpdated  time.Time         `json:"lastUpdated" yaml:"lastUpdated"`
+	Endpoints    []*Endpoint       `json:"endpoints" yaml:"endpoints"`
+	Alarms       []string          `json:"alarms,omitempty" yaml:"alarms,omitempty"`
+	StackOutputs map[string]string `json:"outputs" yaml:"outputs"`
+}
+
+// Endpoint is a load balancer endpoint of a component instance
+type Endpoint struct {
+	// Address is the host:port of the endpoint
+	Address string `json:"address" yaml:"address"`
+	// Protocol is the protocol of the container port behind the endpoint: http, tcp or udp
+	Protocol string `json:"protocol" yaml:"protocol"`
+	// URL is the http:// URL of the endpoint, only set for the ports that serve HTTP
+	URL string `json:"url,omitempty" yaml:"url,omitempty"`
 }
 
 // DeployComponenttResponse holds the created component instance on successful deployment.
@@ -46,26 +87,63 @@ type DeployComponentResponse struct {
 	Err       error
 }
 
-func (component *Component) Display() {
-	fmt.Printf("\nComponent Instance: %s\n\n", component.StackName)
+// NewComponent returns the component instance deployed by a stack, with the endpoints and the alarm ARNs found
+// in the stack outputs
+func NewComponent(stackName string, stackStatus string, lastUpdated time.Time, outputs map[string]string) *Component {
+	endpoints := []*Endpoint{}
+	for _, key := range outputKeys(outputs, endpointOutputSuffix) {
+		endpoint := &Endpoint{
+			Address:  outputs[key],
+			Protocol: outputs[strings.TrimSuffix(key, endpointOutputSuffix)+protocolOutputSuffix],
+		}
+		if endpoint.Protocol == "" {
+			endpoint.Protocol = defaultEndpointProtocol
+		}
+		if endpoint.Protocol == httpProtocol {
+			endpoint.URL = "http://" + endpoint.Address
+		}
+		endpoints = append(endpoints, endpoint)
+	}
 
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Component Instance Attribute", "Value"})
-	table.SetBorder(false)
+	var alarms []string
+	if values := outputValues(outputs, alarmOutputSuffix); len(values) > 0 {
+		alarms = values
+	}
 
-	keys := make([]string, 0, len(component.StackOutputs))
-	for key := range component.StackOutputs {
-		keys = append(keys, key)
+	return &Component{
+		StackName:    stackName,
+		StackStatus:  stackStatus,
+		LastUpdated:  lastUpdated,
+		Endpoints:    endpoints,
+		Alarms:       alarms,
+		StackOutputs: outputs,
+	}
+}
+
+// outputKeys returns the keys of the stack outputs that end with suffix, in order
+func outputKeys(outputs map[string]string, suffix string) []string {
+	keys := make([]string, 0, len(outputs))
+	for key := range outputs {
+		if strings.HasSuffix(key, suffix) {
+			keys = append(keys, key)
+		}
 	}
 	sort.Strings(keys)
+	return keys
+}
 
+// outputValues returns the values of the stack outputs whose keys end with suffix, in key order
+func outputValues(outputs map[string]string, suffix string) []string {
+	keys := outputKeys(outputs, suffix)
+	values := make([]string, 0, len(keys))
 	for _, key := range keys {
-		formattedKey := strings.Title(strings.ToLower(strcase.ToDelimited(key, ' ')))
-		formattedKey = strings.ReplaceAll(formattedKey, "Cloud Formation", "CloudFormation")
-		formattedKey = strings.ReplaceAll(formattedKey, "Ecs", "ECS")
-		table.Append([]string{formattedKey, component.StackOutputs[key]})
+		values = append(values, outputs[key])
 	}
+	return values
+}
 
-	table.Render()
-	fmt.Println("")
+// Display writes the component instance attributes to w as a table
+func (component *Component) Display(w io.Writer) {
+	displayStack(w, fmt.Sprintf("Component Instance: %s", component.StackName), "Component Instance Attribute",
+		component.StackStatus, component.LastUpdated, component.StackOutputs)
 }
@@ -0,0 +1,55 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package types
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	// DashboardConsoleOutputKey is the key of the dashboard stack output that holds the console URL of the dashboard
+	DashboardConsoleOutputKey = "DashboardConsole"
+
+	// ServiceNameOutputKey and LoadBalancerOutputKey are the keys of the component instance stack outputs that
+	// hold the names the dashboard shows the metrics of
+	ServiceNameOutputKey  = "ECSServiceName"
+	LoadBalancerOutputKey = "LoadBalancerFullName"
+)
+
+// DashboardInput holds the fields required to deploy the CloudWatch dashboard of an application
+type DashboardInput struct {
+	ApplicationName string
+	Environment     *ComponentEnvironment
+	Components      []*DashboardComponent
+}
+
+// DashboardName returns the name of the CloudWatch dashboard of the application
+func (input *DashboardInput) DashboardName() string {
+	return fmt.Sprintf("%s-%s", input.Environment.Name, input.ApplicationName)
+}
+
+// DashboardComponent holds the names of the resources of a component instance that the dashboard shows metrics
+// and logs of. LoadBalancerFullName is empty for components without a load balancer.
+type DashboardComponent struct {
+	InstanceName         string
+	ServiceName          string
+	LoadBalancerFullName string
+	LogGroupName         string
+}
+
+// Dashboard represents the deployed CloudWatch dashboard of an application
+type Dashboard struct {
+	StackName    string            `json:"stackName" yaml:"stackName"`
+	StackStatus  string            `json:"status" yaml:"status"`
+	LastUpdated  time.Time         `json:"lastUpdated" yaml:"lastUpdated"`
+	URL          string            `json:"url" yaml:"url"`
+	StackOutputs map[string]string `json:"outputs" yaml:"outputs"`
+}
+
+// Display writes the dashboard attributes to w as a table
+func (dashboard *Dashboard) Display(w io.Writer) {
+	displayStack(w, fmt.Sprintf("Dashboard: %s", dashboard.StackName), "Dashboard Attribute",
+		dashboard.StackStatus, dashboard.LastUpdated, dashboard.StackOutputs)
+}
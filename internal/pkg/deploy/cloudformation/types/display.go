@@ -0,0 +1,87 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/iancoleman/strcase"
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats of the show and deploy commands
+const (
+	TableFormat = "table"
+	JSONFormat  = "json"
+	YAMLFormat  = "yaml"
+)
+
+// ValidateFormat returns an error if the output format is not supported
+func ValidateFormat(format string) error {
+	switch format {
+	case TableFormat, JSONFormat, YAMLFormat:
+		return nil
+	}
+	return fmt.Errorf("Output format %s is not supported, use %s, %s or %s", format, TableFormat, JSONFormat, YAMLFormat)
+}
+
+// Marshal writes a value to w as JSON or YAML, using the keys of the value's json and yaml struct tags
+func Marshal(w io.Writer, format string, value interface{}) error {
+	switch format {
+	case JSONFormat:
+		out, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(out))
+		return err
+	case YAMLFormat:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(value); err != nil {
+			return err
+		}
+		return encoder.Close()
+	}
+	return ValidateFormat(format)
+}
+
+// displayStack writes the status and the outputs of a stack to w as a table, with humanized output keys
+func displayStack(w io.Writer, title string, attributeHeader string, status string, lastUpdated time.Time, outputs map[string]string) {
+	fmt.Fprintf(w, "\n%s\n\n", title)
+
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{attributeHeader, "Value"})
+	table.SetBorder(false)
+
+	if status != "" {
+		table.Append([]string{"Status", status})
+	}
+	if !lastUpdated.IsZero() {
+		table.Append([]string{"Last Updated", lastUpdated.Format(time.RFC3339)})
+	}
+
+	keys := make([]string, 0, len(outputs))
+	for key := range outputs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		formattedKey := strings.Title(strings.ToLower(strcase.ToDelimited(key, ' ')))
+		formattedKey = strings.ReplaceAll(formattedKey, "Cloud Formation", "CloudFormation")
+		formattedKey = strings.ReplaceAll(formattedKey, "Ecs", "ECS")
+		formattedKey = strings.ReplaceAll(formattedKey, "Cpu", "CPU")
+		formattedKey = strings.ReplaceAll(formattedKey, " Arn", " ARN")
+		table.Append([]string{formattedKey, outputs[key]})
+	}
+
+	table.Render()
+	fmt.Fprintln(w, "")
+}
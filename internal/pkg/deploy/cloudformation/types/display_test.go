@@ -0,0 +1,180 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package types
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var lastUpdated = time.Date(2020, 3, 1, 12, 30, 0, 0, time.UTC)
+
+func testComponent() *Component {
+	return NewComponent("oam-ecs-app-web", "UPDATE_COMPLETE", lastUpdated, map[string]string{
+		"ECSServiceConsole":     "https://console.aws.amazon.com/ecs/home",
+		"WebPort8080Endpoint":   "lb.elb.amazonaws.com:8080",
+		"WebPort8080Protocol":   "http",
+		"AdminPort9090Endpoint": "lb.elb.amazonaws.com:9090",
+		"AdminPort9090Protocol": "tcp",
+	})
+}
+
+func TestNewComponentEndpoints(t *testing.T) {
+	component := testComponent()
+
+	require.Equal(t, []*Endpoint{
+		{Address: "lb.elb.amazonaws.com:9090", Protocol: "tcp"},
+		{Address: "lb.elb.amazonaws.com:8080", Protocol: "http", URL: "http://lb.elb.amazonaws.com:8080"},
+	}, component.Endpoints)
+}
+
+func TestNewComponentEndpointsWithoutProtocol(t *testing.T) {
+	component := NewComponent("oam-ecs-app-game", "UPDATE_COMPLETE", lastUpdated, map[string]string{
+		"GamePort7777Endpoint": "lb.elb.amazonaws.com:7777",
+	})
+
+	require.Equal(t, []*Endpoint{{Address: "lb.elb.amazonaws.com:7777", Protocol: "tcp"}}, component.Endpoints)
+}
+
+func TestNewComponentAlarms(t *testing.T) {
+	component := NewComponent("oam-ecs-app-worker", "UPDATE_COMPLETE", lastUpdated, map[string]string{
+		"CpuUtilizationAlarmArn":    "arn:aws:cloudwatch:us-west-2:111122223333:alarm:cpu",
+		"MemoryUtilizationAlarmArn": "arn:aws:cloudwatch:us-west-2:111122223333:alarm:memory",
+	})
+
+	require.Equal(t, []string{"arn:aws:cloudwatch:us-west-2:111122223333:alarm:cpu", "arn:aws:cloudwatch:us-west-2:111122223333:alarm:memory"}, component.Alarms)
+	require.Nil(t, testComponent().Alarms)
+
+	var b bytes.Buffer
+	component.Display(&b)
+	require.Contains(t, b.String(), "CPU Utilization Alarm ARN")
+	require.Contains(t, b.String(), "arn:aws:cloudwatch:us-west-2:111122223333:alarm:memory")
+}
+
+func TestComponentDisplayTable(t *testing.T) {
+	var b bytes.Buffer
+	testComponent().Display(&b)
+
+	require.Contains(t, b.String(), "Component Instance: oam-ecs-app-web")
+	require.Contains(t, b.String(), "UPDATE_COMPLETE")
+	require.Contains(t, b.String(), "2020-03-01T12:30:00Z")
+	require.Contains(t, b.String(), "ECS Service Console")
+	require.Contains(t, b.String(), "Web Port 8080 Endpoint")
+}
+
+func TestMarshalJSON(t *testing.T) {
+	var b bytes.Buffer
+	err := Marshal(&b, JSONFormat, []*Component{testComponent()})
+
+	require.NoError(t, err)
+	require.JSONEq(t, `[{
+  "stackName": "oam-ecs-app-web",
+  "status": "UPDATE_COMPLETE",
+  "lastUpdated": "2020-03-01T12:30:00Z",
+  "endpoints": [
+    {"address": "lb.elb.amazonaws.com:9090", "protocol": "tcp"},
+    {"address": "lb.elb.amazonaws.com:8080", "protocol": "http", "url": "http://lb.elb.amazonaws.com:8080"}
+  ],
+  "outputs": {
+    "AdminPort9090Endpoint": "lb.elb.amazonaws.com:9090",
+    "AdminPort9090Protocol": "tcp",
+    "ECSServiceConsole": "https://console.aws.amazon.com/ecs/home",
+    "WebPort8080Endpoint": "lb.elb.amazonaws.com:8080",
+    "WebPort8080Protocol": "http"
+  }
+}]`, b.String())
+}
+
+func TestMarshalYAML(t *testing.T) {
+	env := &Environment{
+		StackName:    "oam-ecs-environment-default",
+		StackStatus:  "CREATE_COMPLETE",
+		LastUpdated:  lastUpdated,
+		StackOutputs: map[string]string{"VpcId": "vpc-1234"},
+	}
+
+	var b bytes.Buffer
+	err := Marshal(&b, YAMLFormat, env)
+
+	require.NoError(t, err)
+	require.YAMLEq(t, `
+stackName: oam-ecs-environment-default
+status: CREATE_COMPLETE
+lastUpdated: 2020-03-01T12:30:00Z
+outputs:
+  VpcId: vpc-1234
+`, b.String())
+}
+
+func TestMarshalUnsupportedFormat(t *testing.T) {
+	var b bytes.Buffer
+	err := Marshal(&b, "xml", testComponent())
+
+	require.EqualError(t, err, "Output format xml is not supported, use table, json or yaml")
+}
+
+func TestDisplayApplications(t *testing.T) {
+	var b bytes.Buffer
+	DisplayApplications(&b, []*Application{
+		{
+			Name:         "twitter-bot",
+			Environment:  "default",
+			Status:       ApplicationStatusComplete,
+			LastDeployed: lastUpdated,
+			Components:   []*ApplicationComponent{{InstanceName: "web-front-end"}, {InstanceName: "backend-svc"}},
+		},
+	})
+
+	require.Contains(t, b.String(), "twitter-bot")
+	require.Contains(t, b.String(), "| default     |          2 | COMPLETE | 2020-03-01T12:30:00Z")
+}
+
+func TestDisplayNoApplications(t *testing.T) {
+	var b bytes.Buffer
+	DisplayApplications(&b, []*Application{})
+
+	require.Equal(t, "No applications found.\n", b.String())
+}
+
+func TestComponentStatusDisplay(t *testing.T) {
+	status := &ComponentStatus{
+		InstanceName: "web-front-end",
+		StackName:    "oam-ecs-app-web-front-end",
+		Service: &ServiceStatus{
+			Name:         "oam-ecs-app-web-front-end-Service",
+			Status:       "ACTIVE",
+			DesiredCount: 2,
+			RunningCount: 1,
+			PendingCount: 1,
+			Deployments: []*ServiceDeployment{
+				{Status: "PRIMARY", RolloutState: "IN_PROGRESS", DesiredCount: 2, RunningCount: 1, PendingCount: 1, UpdatedAt: lastUpdated},
+			},
+			Events: []*ServiceEvent{
+				{CreatedAt: lastUpdated, Message: "(service web) has started 1 tasks"},
+			},
+		},
+		TargetGroups: []*TargetGroupHealth{
+			{Targets: []*TargetHealth{{ID: "10.0.1.5", Port: 80, State: "unhealthy", Reason: "Target.FailedHealthChecks", Description: "Health checks failed"}}},
+		},
+	}
+
+	var b bytes.Buffer
+	status.Display(&b)
+
+	require.Contains(t, b.String(), "Component Instance: web-front-end")
+	require.Contains(t, b.String(), "| ACTIVE |       2 |       1 |       1")
+	require.Contains(t, b.String(), "PRIMARY    | IN_PROGRESS")
+	require.Contains(t, b.String(), "10.0.1.5 |   80 | unhealthy | Target.FailedHealthChecks: Health checks failed")
+	require.Contains(t, b.String(), "(service web) has started 1 tasks")
+}
+
+func TestNewLogEvent(t *testing.T) {
+	event := NewLogEvent("1", lastUpdated, "oam-ecs/nginx/0123456789abcdef", "GET / 200\n")
+
+	require.Equal(t, "nginx", event.Container)
+	require.Equal(t, "0123456789abcdef", event.TaskID)
+	require.Equal(t, "GET / 200", event.Message)
+}
@@ -4,22 +4,50 @@ package types
 
 import (
 	"fmt"
-	"os"
-	"sort"
-	"strings"
+	"io"
+	"strconv"
+	"time"
+)
 
-	"github.com/iancoleman/strcase"
-	"github.com/olekukonko/tablewriter"
+// Keys of the environment stack outputs that hold the log group defaults
+const (
+	LogRetentionInDaysOutputKey = "LogRetentionInDays"
+	LogKMSKeyARNOutputKey       = "LogKmsKeyArn"
+	LogDeletionPolicyOutputKey  = "LogDeletionPolicy"
 )
 
 // EnvironmentInput holds the fields required to interact with an environment.
+//
+// Settings left nil were not given: a new environment takes the template defaults for them,
+// and an existing environment keeps the values it was deployed with.
 type EnvironmentInput struct {
+	EC2Capacity        *bool
+	EC2InstanceType    *string
+	EC2GPU             *bool
+	EC2MaxSize         *int
+	FileSystem         *bool
+	LogRetentionInDays *int
+	LogKMSKeyARN       *string
+	LogDeletionPolicy  *string
+
+	// ExistingParameters holds the parameter keys of the deployed environment stack, empty before it is created
+	ExistingParameters map[string]bool
+}
+
+// LogSettings holds the retention, encryption and deletion policy of CloudWatch Logs log groups.
+// A zero RetentionInDays keeps the logs forever, and an empty DeletionPolicy deletes the log group with its stack.
+type LogSettings struct {
+	RetentionInDays int
+	KMSKeyARN       string
+	DeletionPolicy  string
 }
 
 // Environment represents the configuration of a particular environment
 type Environment struct {
-	StackName    string
-	StackOutputs map[string]string
+	StackName    string            `json:"stackName" yaml:"stackName"`
+	StackStatus  string            `json:"status" yaml:"status"`
+	LastUpdated  time.Time         `json:"lastUpdated" yaml:"lastUpdated"`
+	StackOutputs map[string]string `json:"outputs" yaml:"outputs"`
 }
 
 // CreateEnvironmentResponse holds the created environment on successful deployment.
@@ -29,26 +57,20 @@ type CreateEnvironmentResponse struct {
 	Err error
 }
 
-func (env *Environment) Display() {
-	fmt.Printf("\nEnvironment: %s\n\n", env.StackName)
-
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Environment Attribute", "Value"})
-	table.SetBorder(false)
+// Display writes the environment attributes to w as a table
+func (env *Environment) Display(w io.Writer) {
+	displayStack(w, fmt.Sprintf("Environment: %s", env.StackName), "Environment Attribute",
+		env.StackStatus, env.LastUpdated, env.StackOutputs)
+}
 
-	keys := make([]string, 0, len(env.StackOutputs))
-	for key := range env.StackOutputs {
-		keys = append(keys, key)
+// LogSettings returns the log group defaults the environment stack was deployed with
+func (env *Environment) LogSettings() *LogSettings {
+	settings := &LogSettings{
+		KMSKeyARN:      env.StackOutputs[LogKMSKeyARNOutputKey],
+		DeletionPolicy: env.StackOutputs[LogDeletionPolicyOutputKey],
 	}
-	sort.Strings(keys)
-
-	for _, key := range keys {
-		formattedKey := strings.Title(strings.ToLower(strcase.ToDelimited(key, ' ')))
-		formattedKey = strings.ReplaceAll(formattedKey, "Cloud Formation", "CloudFormation")
-		formattedKey = strings.ReplaceAll(formattedKey, "Ecs", "ECS")
-		table.Append([]string{formattedKey, env.StackOutputs[key]})
+	if days, err := strconv.Atoi(env.StackOutputs[LogRetentionInDaysOutputKey]); err == nil {
+		settings.RetentionInDays = days
 	}
-
-	table.Render()
-	fmt.Println("")
+	return settings
 }
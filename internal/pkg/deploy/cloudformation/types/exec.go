@@ -0,0 +1,31 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package types
+
+import (
+	"time"
+)
+
+// Task represents a running ECS task of a component instance's service
+type Task struct {
+	ARN        string
+	ID         string
+	StartedAt  time.Time
+	Containers []*TaskContainer
+}
+
+// TaskContainer represents a container of a running ECS task
+type TaskContainer struct {
+	Name      string
+	RuntimeID string
+	// ExecAgentRunning is whether the ECS Exec agent runs in the container, which is only the case
+	// for tasks started after ECS Exec was enabled on the service
+	ExecAgentRunning bool
+}
+
+// ExecSession holds the Session Manager session started by ECS Exec, in the form expected by the session-manager-plugin
+type ExecSession struct {
+	SessionID  string `json:"SessionId"`
+	StreamURL  string `json:"StreamUrl"`
+	TokenValue string `json:"TokenValue"`
+}
@@ -0,0 +1,34 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package types
+
+import (
+	"strings"
+	"time"
+)
+
+// LogEvent is a line written by a container of a component instance to its log group
+type LogEvent struct {
+	ID        string    `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	Container string    `json:"container"`
+	TaskID    string    `json:"taskId"`
+	Message   string    `json:"message"`
+}
+
+// NewLogEvent returns a log event, with the container and the task read from the name of the awslogs stream,
+// which is <prefix>/<container>/<task>
+func NewLogEvent(id string, timestamp time.Time, streamName string, message string) *LogEvent {
+	event := &LogEvent{
+		ID:        id,
+		Timestamp: timestamp,
+		Message:   strings.TrimRight(message, "\n"),
+	}
+	if parts := strings.SplitN(streamName, "/", 3); len(parts) == 3 {
+		event.Container = parts[1]
+		event.TaskID = parts[2]
+	} else {
+		event.Container = streamName
+	}
+	return event
+}
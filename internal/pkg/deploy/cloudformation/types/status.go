@@ -0,0 +1,131 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package types
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// ComponentResources holds the physical IDs of the resources in a component instance stack that have a live status
+type ComponentResources struct {
+	StackName       string
+	ServiceARN      string
+	TargetGroupARNs []string
+}
+
+// ComponentStatus represents the live status of a component instance's ECS service and load balancer targets
+type ComponentStatus struct {
+	InstanceName string               `json:"instanceName" yaml:"instanceName"`
+	StackName    string               `json:"stackName" yaml:"stackName"`
+	Service      *ServiceStatus       `json:"service" yaml:"service"`
+	TargetGroups []*TargetGroupHealth `json:"targetGroups" yaml:"targetGroups"`
+}
+
+// ServiceStatus represents the task counts, deployments and recent events of an ECS service
+type ServiceStatus struct {
+	Name         string               `json:"name" yaml:"name"`
+	Status       string               `json:"status" yaml:"status"`
+	DesiredCount int64                `json:"desiredCount" yaml:"desiredCount"`
+	RunningCount int64                `json:"runningCount" yaml:"runningCount"`
+	PendingCount int64                `json:"pendingCount" yaml:"pendingCount"`
+	Deployments  []*ServiceDeployment `json:"deployments" yaml:"deployments"`
+	Events       []*ServiceEvent      `json:"events" yaml:"events"`
+}
+
+// ServiceDeployment represents a deployment of an ECS service, the primary deployment is the one being rolled out
+type ServiceDeployment struct {
+	ID                 string    `json:"id" yaml:"id"`
+	Status             string    `json:"status" yaml:"status"`
+	TaskDefinition     string    `json:"taskDefinition" yaml:"taskDefinition"`
+	RolloutState       string    `json:"rolloutState" yaml:"rolloutState"`
+	RolloutStateReason string    `json:"rolloutStateReason,omitempty" yaml:"rolloutStateReason,omitempty"`
+	DesiredCount       int64     `json:"desiredCount" yaml:"desiredCount"`
+	RunningCount       int64     `json:"runningCount" yaml:"runningCount"`
+	PendingCount       int64     `json:"pendingCount" yaml:"pendingCount"`
+	FailedTasks        int64     `json:"failedTasks" yaml:"failedTasks"`
+	UpdatedAt          time.Time `json:"updatedAt" yaml:"updatedAt"`
+}
+
+// ServiceEvent is a message that ECS recorded about a service
+type ServiceEvent struct {
+	CreatedAt time.Time `json:"createdAt" yaml:"createdAt"`
+	Message   string    `json:"message" yaml:"message"`
+}
+
+// TargetGroupHealth represents the health of the targets registered with a load balancer target group
+type TargetGroupHealth struct {
+	ARN     string          `json:"arn" yaml:"arn"`
+	Targets []*TargetHealth `json:"targets" yaml:"targets"`
+}
+
+// TargetHealth represents the health of a target, as seen by the load balancer
+type TargetHealth struct {
+	ID          string `json:"id" yaml:"id"`
+	Port        int64  `json:"port" yaml:"port"`
+	State       string `json:"state" yaml:"state"`
+	Reason      string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// Display writes the component instance status to w as tables
+func (status *ComponentStatus) Display(w io.Writer) {
+	fmt.Fprintf(w, "\nComponent Instance: %s\n\n", status.InstanceName)
+
+	service := status.Service
+	table := newTable(w, "Service", "Status", "Desired", "Running", "Pending")
+	table.Append([]string{service.Name, service.Status, strconv.FormatInt(service.DesiredCount, 10),
+		strconv.FormatInt(service.RunningCount, 10), strconv.FormatInt(service.PendingCount, 10)})
+	table.Render()
+	fmt.Fprintln(w, "")
+
+	if len(service.Deployments) > 0 {
+		table = newTable(w, "Deployment", "Rollout", "Desired", "Running", "Pending", "Failed", "Updated")
+		for _, deployment := range service.Deployments {
+			rollout := deployment.RolloutState
+			if deployment.RolloutStateReason != "" {
+				rollout = fmt.Sprintf("%s: %s", rollout, deployment.RolloutStateReason)
+			}
+			table.Append([]string{deployment.Status, rollout, strconv.FormatInt(deployment.DesiredCount, 10),
+				strconv.FormatInt(deployment.RunningCount, 10), strconv.FormatInt(deployment.PendingCount, 10),
+				strconv.FormatInt(deployment.FailedTasks, 10), deployment.UpdatedAt.Format(time.RFC3339)})
+		}
+		table.Render()
+		fmt.Fprintln(w, "")
+	}
+
+	for _, targetGroup := range status.TargetGroups {
+		table = newTable(w, "Target", "Port", "Health", "Reason")
+		for _, target := range targetGroup.Targets {
+			reason := target.Reason
+			if target.Description != "" {
+				reason = fmt.Sprintf("%s: %s", reason, target.Description)
+			}
+			table.Append([]string{target.ID, strconv.FormatInt(target.Port, 10), target.State, reason})
+		}
+		table.Render()
+		fmt.Fprintln(w, "")
+	}
+
+	if len(service.Events) > 0 {
+		table = newTable(w, "Event Time", "Message")
+		for _, event := range service.Events {
+			table.Append([]string{event.CreatedAt.Format(time.RFC3339), event.Message})
+		}
+		table.Render()
+		fmt.Fprintln(w, "")
+	}
+}
+
+// newTable returns a borderless table with the given header
+func newTable(w io.Writer, header ...string) *tablewriter.Table {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(header)
+	table.SetBorder(false)
+	table.SetAutoWrapText(false)
+	return table
+}
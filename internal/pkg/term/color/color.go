@@ -18,6 +18,7 @@ import (
 var (
 	Grey          = color.New(color.FgWhite)
 	Red           = color.New(color.FgHiRed)
+	Green         = color.New(color.FgGreen)
 	Cyan          = color.New(color.FgCyan)
 	BoldUnderline = color.New(color.Bold, color.Underline)
 	Magenta       = color.New(color.FgMagenta)
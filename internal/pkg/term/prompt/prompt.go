@@ -0,0 +1,28 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package prompt provides functionality to ask the user questions on the terminal.
+package prompt
+
+import (
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/awslabs/amazon-ecs-for-open-application-model/internal/pkg/term/color"
+)
+
+// Prompt asks questions on the terminal.
+type Prompt struct{}
+
+// New returns a prompt for the terminal.
+func New() Prompt {
+	return Prompt{}
+}
+
+// SelectOne asks the user to choose one of the options, and returns the chosen option.
+func (p Prompt) SelectOne(message string, options []string) (string, error) {
+	var answer string
+	err := survey.AskOne(&survey.Select{
+		Message: color.HighlightUserInput(message),
+		Options: options,
+	}, &answer)
+	return answer, err
+}
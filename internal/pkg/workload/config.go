@@ -0,0 +1,61 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package workload
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// systemDirectories are the directories of container images that config files cannot be written to. Config files
+// are written to a read-only volume mounted over their directory, which would hide the image's files in it.
+var systemDirectories = map[string]bool{
+	"/":              true,
+	"/bin":           true,
+	"/boot":          true,
+	"/dev":           true,
+	"/etc":           true,
+	"/etc/ssl":       true,
+	"/etc/ssl/certs": true,
+	"/home":          true,
+	"/lib":           true,
+	"/lib64":         true,
+	"/opt":           true,
+	"/proc":          true,
+	"/root":          true,
+	"/run":           true,
+	"/sbin":          true,
+	"/srv":           true,
+	"/sys":           true,
+	"/tmp":           true,
+	"/usr":           true,
+	"/usr/bin":       true,
+	"/usr/lib":       true,
+	"/usr/local":     true,
+	"/usr/local/bin": true,
+	"/usr/local/lib": true,
+	"/usr/sbin":      true,
+	"/usr/share":     true,
+	"/var":           true,
+	"/var/cache":     true,
+	"/var/lib":       true,
+	"/var/log":       true,
+	"/var/run":       true,
+	"/var/tmp":       true,
+}
+
+// ConfigFilePathProblem returns why a config file cannot be written to the given path, or an empty string if it can.
+// ECS mounts volumes on directories, so each directory with config files is mounted read-only over the image's
+// directory and must be dedicated to config files. Only the system directories are rejected, since the files of
+// other directories in the image are not known until the image is pulled.
+func ConfigFilePathProblem(filePath string) string {
+	if !path.IsAbs(filePath) || strings.HasSuffix(filePath, "/") || path.Clean(filePath) != filePath {
+		return fmt.Sprintf("Config file path %s must be an absolute file path", filePath)
+	}
+	if directory := path.Dir(filePath); systemDirectories[directory] {
+		return fmt.Sprintf("Config file path %s is in the system directory %s, which the config volume would hide, use a dedicated directory like %s/<app>", filePath, directory, strings.TrimSuffix(directory, "/"))
+	}
+	return ""
+}
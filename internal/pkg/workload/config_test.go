@@ -0,0 +1,22 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package workload
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigFilePathProblem(t *testing.T) {
+	require.Empty(t, ConfigFilePathProblem("/etc/access/default_user.txt"))
+	require.Empty(t, ConfigFilePathProblem("/var/run/db/db-data"))
+
+	require.Equal(t, "Config file path /etc/app.conf is in the system directory /etc, which the config volume would hide, use a dedicated directory like /etc/<app>",
+		ConfigFilePathProblem("/etc/app.conf"))
+	require.Contains(t, ConfigFilePathProblem("/var/run/x"), "system directory /var/run")
+	require.Contains(t, ConfigFilePathProblem("/app.conf"), "system directory /,")
+	require.Equal(t, "Config file path config/app.conf must be an absolute file path", ConfigFilePathProblem("config/app.conf"))
+	require.Contains(t, ConfigFilePathProblem("/opt/app/"), "must be an absolute file path")
+	require.Contains(t, ConfigFilePathProblem("/opt/app/../app.conf"), "must be an absolute file path")
+}
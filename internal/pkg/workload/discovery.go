@@ -0,0 +1,80 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package workload
+
+import (
+	"github.com/oam-dev/oam-go-sdk/apis/core.oam.dev/v1alpha1"
+)
+
+// DiscoveryReference is a pair of component instances of an application, where the parameter values of the client
+// refer to the discovery hostname of the server
+type DiscoveryReference struct {
+	Client string
+	Server string
+}
+
+// discoveryHostnameReferences lists the component instances whose discovery hostnames the parameter values of a
+// component instance refer to, in the order they are first referred to
+func discoveryHostnameReferences(component *v1alpha1.ComponentConfiguration) []string {
+	references := []string{}
+	seen := make(map[string]bool)
+	for _, value := range component.ParameterValues {
+		expression, ok := ParseParameterExpression(value.Value)
+		if !ok || expression.Function != DiscoveryHostnameFunction || len(expression.Args) == 0 {
+			continue
+		}
+		if !seen[expression.Args[0]] {
+			seen[expression.Args[0]] = true
+			references = append(references, expression.Args[0])
+		}
+	}
+	return references
+}
+
+// DiscoveryReferences lists the discovery hostname references between the given component instance and the
+// component instances deployed before it, in the deployment order of the application. The security group rules
+// that let a client reach a server belong to the stack of whichever of the two is deployed last, which imports the
+// security group of the other one. Component instances can then refer to each other's hostnames without constraining
+// the deployment order, and the exports a stack imports always exist when it is deployed.
+func DiscoveryReferences(application *v1alpha1.ApplicationConfiguration, instanceName string) []DiscoveryReference {
+	// Without a deployment order, as for output reference cycles, the order of the application configuration is used
+	order, err := DeploymentOrder(application)
+	if err != nil {
+		order = []*v1alpha1.ComponentConfiguration{}
+		for i := range application.Spec.Components {
+			order = append(order, &application.Spec.Components[i])
+		}
+	}
+
+	deployedBefore := make(map[string]bool)
+	var instance *v1alpha1.ComponentConfiguration
+	for _, component := range order {
+		if component.InstanceName == instanceName {
+			instance = component
+			break
+		}
+		deployedBefore[component.InstanceName] = true
+	}
+	if instance == nil {
+		return []DiscoveryReference{}
+	}
+
+	references := []DiscoveryReference{}
+	for _, component := range order {
+		if !deployedBefore[component.InstanceName] {
+			continue
+		}
+		for _, server := range discoveryHostnameReferences(component) {
+			if server == instanceName {
+				references = append(references, DiscoveryReference{Client: component.InstanceName, Server: instanceName})
+			}
+		}
+		for _, server := range discoveryHostnameReferences(instance) {
+			if server == component.InstanceName {
+				references = append(references, DiscoveryReference{Client: instanceName, Server: server})
+			}
+		}
+	}
+	return references
+}
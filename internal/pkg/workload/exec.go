@@ -0,0 +1,43 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package workload
+
+import (
+	"fmt"
+
+	"github.com/oam-dev/oam-go-sdk/apis/core.oam.dev/v1alpha1"
+)
+
+// ExecTrait is the name of the trait that lets oam-ecs app exec run commands in the containers of a component
+// instance, through ECS Exec
+const ExecTrait = "exec"
+
+// ParseExec reads the properties of the exec trait, which takes none:
+//
+//	traits:
+//	  - name: exec
+//
+// Returns a message for each invalid property by its path in the properties, or nil if the properties are valid.
+func ParseExec(properties map[string]interface{}) map[string]string {
+	problems := make(map[string]string)
+
+	for name := range properties {
+		problems[name] = fmt.Sprintf("Unknown property %s, the exec trait takes no properties", name)
+	}
+
+	if len(problems) > 0 {
+		return problems
+	}
+	return nil
+}
+
+// HasExecTrait returns whether the exec trait is bound to the component instance
+func HasExecTrait(componentConfiguration *v1alpha1.ComponentConfiguration) bool {
+	for _, trait := range componentConfiguration.Traits {
+		if trait.Name == ExecTrait {
+			return true
+		}
+	}
+	return false
+}
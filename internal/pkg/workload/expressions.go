@@ -0,0 +1,102 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package workload
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// ImportValueFunction is the parameter expression function that reads a CloudFormation export,
+	// such as the endpoint of an internal Server component: [importValue(export-name)]
+	ImportValueFunction = "importValue"
+
+	// DiscoveryHostnameFunction is the parameter expression function that gives the hostname another component
+	// instance of the application is registered under in Cloud Map: [discoveryHostname(instance-name)]
+	DiscoveryHostnameFunction = "discoveryHostname"
+
+	// ComponentOutputFunction is the parameter expression function that reads a stack output of another component
+	// instance of the application, once that component instance is deployed: [componentOutput(instance-name, OutputKey)]
+	ComponentOutputFunction = "componentOutput"
+)
+
+var (
+	parameterExpressionPattern = regexp.MustCompile(`^\[([A-Za-z]+)\((.*)\)\]$`)
+	exportNamePattern          = regexp.MustCompile(`^[A-Za-z0-9:-]+$`)
+	instanceNamePattern        = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]*[A-Za-z0-9])?$`)
+	outputKeyPattern           = regexp.MustCompile(`^[A-Za-z0-9]+$`)
+)
+
+// parameterFunctions lists the supported parameter expression functions, with the number of arguments they take
+var parameterFunctions = map[string]int{
+	ImportValueFunction:       1,
+	DiscoveryHostnameFunction: 1,
+	ComponentOutputFunction:   2,
+}
+
+// DiscoveryHostname is the hostname a component instance is registered under in the Cloud Map namespace
+// of the environment
+func DiscoveryHostname(instanceName string, applicationName string, environmentName string) string {
+	return fmt.Sprintf("%s.%s.%s.local", instanceName, applicationName, environmentName)
+}
+
+// ParameterExpression is a parameter value of the form [function(arg, ...)], which is resolved when the template
+// of the component instance is rendered rather than used as a literal string
+type ParameterExpression struct {
+	Function string
+	Args     []string
+}
+
+// ParseParameterExpression parses a parameter value as an expression. Returns false if the value does not call
+// one of the supported functions, in which case the value is used as a literal string.
+func ParseParameterExpression(value string) (*ParameterExpression, bool) {
+	match := parameterExpressionPattern.FindStringSubmatch(value)
+	if match == nil {
+		return nil, false
+	}
+	if _, ok := parameterFunctions[match[1]]; !ok {
+		return nil, false
+	}
+
+	expression := &ParameterExpression{
+		Function: match[1],
+		Args:     []string{},
+	}
+	if strings.TrimSpace(match[2]) != "" {
+		for _, arg := range strings.Split(match[2], ",") {
+			expression.Args = append(expression.Args, strings.TrimSpace(arg))
+		}
+	}
+	return expression, true
+}
+
+// Validate checks the number and the format of the expression's arguments
+func (expression *ParameterExpression) Validate() error {
+	if count := parameterFunctions[expression.Function]; len(expression.Args) != count {
+		return fmt.Errorf("%s takes %d arguments, got %d", expression.Function, count, len(expression.Args))
+	}
+
+	switch expression.Function {
+	case ImportValueFunction:
+		if !exportNamePattern.MatchString(expression.Args[0]) {
+			return fmt.Errorf("%s takes the name of a CloudFormation export, got %s", expression.Function, expression.Args[0])
+		}
+	case DiscoveryHostnameFunction, ComponentOutputFunction:
+		if !instanceNamePattern.MatchString(expression.Args[0]) {
+			return fmt.Errorf("%s takes the name of a component instance, got %s", expression.Function, expression.Args[0])
+		}
+		if expression.Function == ComponentOutputFunction && !outputKeyPattern.MatchString(expression.Args[1]) {
+			return fmt.Errorf("%s takes the key of a stack output, got %s", expression.Function, expression.Args[1])
+		}
+	}
+	return nil
+}
+
+// isParameterExpressionLike checks whether a parameter value looks like an expression, even one calling
+// an unsupported function
+func isParameterExpressionLike(value string) bool {
+	return parameterExpressionPattern.MatchString(value)
+}
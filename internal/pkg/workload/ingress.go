@@ -0,0 +1,125 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package workload
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+)
+
+const (
+	// IngressPolicyTrait is the name of the trait that restricts who can reach the endpoints of a Server component
+	IngressPolicyTrait = "ingress-policy"
+
+	// ExposureTrait is the name of the trait that selects whether the load balancer of a Server component
+	// is internet-facing or internal to the environment's VPC
+	ExposureTrait    = "exposure"
+	PublicExposure   = "public"
+	InternalExposure = "internal"
+)
+
+var prefixListPattern = regexp.MustCompile(`^pl-[0-9a-f]{8,17}$`)
+
+// IngressPolicy lists the sources allowed to reach the load balancer of a Server component.
+// CIDRs are normalized, sorted and deduplicated, so that the security group rules render the same way every time.
+type IngressPolicy struct {
+	CIDRs       []string
+	IPv6CIDRs   []string
+	PrefixLists []string
+	// VPC allows the CIDR block of the environment's VPC
+	VPC bool
+	// PreserveClientIP makes the load balancer pass the client IP addresses to the containers, which then only accept
+	// traffic from the allowed sources. Otherwise the containers see the load balancer's addresses, and only accept
+	// traffic from the load balancer's subnets. Network Load Balancers always preserve the client IP addresses of UDP
+	// traffic, so UDP ports accept traffic from the allowed sources either way.
+	PreserveClientIP bool
+}
+
+// DefaultIngressPolicy allows traffic from anywhere on the IPv4 internet to public load balancers, and from the
+// environment's VPC to internal load balancers, for Server components without the ingress-policy trait. The
+// load balancer does not preserve the client IP addresses, so that the containers' TCP ports only accept traffic
+// from the load balancer's subnets.
+func DefaultIngressPolicy(exposure string) *IngressPolicy {
+	if exposure == InternalExposure {
+		return &IngressPolicy{
+			CIDRs:       []string{},
+			IPv6CIDRs:   []string{},
+			PrefixLists: []string{},
+			VPC:         true,
+		}
+	}
+	return &IngressPolicy{
+		CIDRs:       []string{"0.0.0.0/0"},
+		IPv6CIDRs:   []string{},
+		PrefixLists: []string{},
+	}
+}
+
+// ParseIngressPolicy reads the properties of the ingress-policy trait:
+//
+//	allowedCidrs:
+//	  - 203.0.113.0/24
+//	  - 2001:db8::/32
+//	allowedPrefixLists:
+//	  - pl-0123456789abcdef0
+//
+// Returns a message for each invalid property by its path in the properties, or nil if the properties are valid.
+func ParseIngressPolicy(properties map[string]interface{}) (*IngressPolicy, map[string]string) {
+	policy := &IngressPolicy{}
+	problems := make(map[string]string)
+
+	for name := range properties {
+		if name != "allowedCidrs" && name != "allowedPrefixLists" {
+			problems[name] = fmt.Sprintf("Unknown property %s, the ingress-policy trait takes allowedCidrs and allowedPrefixLists", name)
+		}
+	}
+
+	var cidrs, ipv6CIDRs []string
+	if value, ok := properties["allowedCidrs"]; ok {
+		list, valid := stringList(value)
+		if !valid {
+			problems["allowedCidrs"] = "Allowed CIDRs must be a list of strings"
+		}
+		for i, cidr := range list {
+			ip, network, err := net.ParseCIDR(cidr)
+			if err != nil {
+				problems[fmt.Sprintf("allowedCidrs[%d]", i)] = fmt.Sprintf("Allowed CIDR must be an IPv4 or IPv6 CIDR block like 203.0.113.0/24, got %s", cidr)
+				continue
+			}
+			if ip.To4() != nil {
+				cidrs = append(cidrs, network.String())
+			} else {
+				ipv6CIDRs = append(ipv6CIDRs, network.String())
+			}
+		}
+	}
+
+	var prefixLists []string
+	if value, ok := properties["allowedPrefixLists"]; ok {
+		list, valid := stringList(value)
+		if !valid {
+			problems["allowedPrefixLists"] = "Allowed prefix lists must be a list of strings"
+		}
+		for i, prefixList := range list {
+			if !prefixListPattern.MatchString(prefixList) {
+				problems[fmt.Sprintf("allowedPrefixLists[%d]", i)] = fmt.Sprintf("Allowed prefix list must be a prefix list ID like pl-0123456789abcdef0, got %s", prefixList)
+			}
+		}
+		prefixLists = list
+	}
+
+	if len(problems) == 0 && len(cidrs)+len(ipv6CIDRs)+len(prefixLists) == 0 {
+		problems["allowedCidrs"] = "The ingress-policy trait must allow at least one CIDR or prefix list"
+	}
+	if len(problems) > 0 {
+		return nil, problems
+	}
+
+	policy.CIDRs = sortedUnique(cidrs)
+	policy.IPv6CIDRs = sortedUnique(ipv6CIDRs)
+	policy.PrefixLists = sortedUnique(prefixLists)
+	policy.PreserveClientIP = true
+	return policy, nil
+}
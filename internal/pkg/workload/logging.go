@@ -0,0 +1,244 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package workload
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+const (
+	// LoggingTrait is the name of the trait that selects where the containers of a component instance send their logs
+	LoggingTrait = "logging"
+
+	AWSLogsDriver  = "awslogs"
+	FireLensDriver = "firelens"
+	NoLogsDriver   = "none"
+
+	S3LogDestination         = "s3"
+	FirehoseLogDestination   = "firehose"
+	OpenSearchLogDestination = "opensearch"
+
+	// DeleteLogGroup and RetainLogGroup are the deletion policies of a log group, when its stack is deleted
+	DeleteLogGroup = "Delete"
+	RetainLogGroup = "Retain"
+)
+
+var (
+	// logRetentionDays are the retention periods CloudWatch Logs accepts
+	logRetentionDays = map[int]bool{
+		1: true, 3: true, 5: true, 7: true, 14: true, 30: true, 60: true, 90: true, 120: true, 150: true, 180: true,
+		365: true, 400: true, 545: true, 731: true, 1096: true, 1827: true, 2192: true, 2557: true, 2922: true,
+		3288: true, 3653: true,
+	}
+
+	kmsKeyARNPattern          = regexp.MustCompile(`^arn:(aws[a-z-]*|\$\{AWS::Partition\}):kms:\S+:(\d{12}|\$\{AWS::AccountId\}):key/[A-Za-z0-9-]+$`)
+	bucketNamePattern         = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+	deliveryStreamPattern     = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,64}$`)
+	openSearchEndpointPattern = regexp.MustCompile(`^(?:search|vpc)-([a-z][a-z0-9-]{2,27})-[a-z0-9]+\.[a-z0-9-]+\.es\.amazonaws\.com$`)
+	openSearchIndexPattern    = regexp.MustCompile(`^[a-z0-9_-]+$`)
+)
+
+// Logging holds the log settings of the logging trait. The awslogs driver sends the logs to the component
+// instance's CloudWatch Logs log group, the firelens driver routes them through a Fluent Bit container
+// to the destination, and the none driver drops them.
+type Logging struct {
+	Driver string
+	// RetentionInDays, KMSKeyARN and DeletionPolicy apply to the log group, and override the environment's defaults
+	// when set
+	RetentionInDays int
+	KMSKeyARN       string
+	DeletionPolicy  string
+
+	Destination    string
+	Bucket         string
+	Prefix         string
+	DeliveryStream string
+	Endpoint       string
+	Index          string
+	// Domain is the name of the OpenSearch domain of the endpoint, which the task role may write to
+	Domain string
+}
+
+// logRouterName is the name of the Fluent Bit container that routes the logs of the other containers
+const logRouterName = "log_router"
+
+// LogRouter is the FireLens container that the firelens logging driver adds to the task, before the sidecars
+func LogRouter() *Sidecar {
+	return &Sidecar{
+		Name:      logRouterName,
+		Image:     "public.ecr.aws/aws-observability/aws-for-fluent-bit:stable",
+		CPU:       0.0625,
+		MemoryMiB: 128,
+		Essential: true,
+		Condition: "START",
+		FireLens:  true,
+	}
+}
+
+const (
+	logRetentionMessage = "Log retention must be one of the periods CloudWatch Logs supports, like 7, 30, 90 or 365 days, got %v"
+	kmsKeyARNMessage    = "KMS key ARN must look like arn:aws:kms:<region>:<account>:key/<key id>, got %v"
+)
+
+// deletionPolicyProblem explains why a value is not a deletion policy of a log group, or returns an empty string
+func deletionPolicyProblem(value interface{}) string {
+	switch value {
+	case DeleteLogGroup, RetainLogGroup:
+		return ""
+	case "Snapshot":
+		return "Log groups cannot be snapshotted by CloudFormation, use the Retain deletion policy to keep the logs"
+	default:
+		return fmt.Sprintf("Log group deletion policy must be Delete or Retain, got %v", value)
+	}
+}
+
+// ValidateLogGroupSettings checks the log group defaults of an environment, which take the same values as the
+// retentionInDays, kmsKeyArn and deletionPolicy properties of the logging trait. A zero retention keeps the logs
+// forever, and an empty KMS key ARN leaves the log groups unencrypted by KMS.
+func ValidateLogGroupSettings(retentionInDays int, kmsKeyARN string, deletionPolicy string) error {
+	if retentionInDays != 0 && !logRetentionDays[retentionInDays] {
+		return fmt.Errorf(logRetentionMessage, retentionInDays)
+	}
+	if kmsKeyARN != "" && !kmsKeyARNPattern.MatchString(kmsKeyARN) {
+		return fmt.Errorf(kmsKeyARNMessage, kmsKeyARN)
+	}
+	if message := deletionPolicyProblem(deletionPolicy); message != "" {
+		return errors.New(message)
+	}
+	return nil
+}
+
+// DefaultLogging sends the logs of component instances without the logging trait to CloudWatch Logs
+func DefaultLogging() *Logging {
+	return &Logging{Driver: AWSLogsDriver}
+}
+
+var (
+	// driverProperties lists the properties each logging driver takes
+	driverProperties = map[string]map[string]bool{
+		AWSLogsDriver: {"driver": true, "retentionInDays": true, "kmsKeyArn": true, "deletionPolicy": true},
+		NoLogsDriver:  {"driver": true},
+	}
+	// destinationProperties lists the properties the firelens driver takes for each destination
+	destinationProperties = map[string]map[string]bool{
+		S3LogDestination:         {"driver": true, "destination": true, "bucket": true, "prefix": true},
+		FirehoseLogDestination:   {"driver": true, "destination": true, "deliveryStream": true},
+		OpenSearchLogDestination: {"driver": true, "destination": true, "endpoint": true, "index": true},
+	}
+)
+
+// ParseLogging reads the properties of the logging trait:
+//
+//	driver: awslogs
+//	retentionInDays: 30
+//	kmsKeyArn: arn:aws:kms:us-west-2:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab
+//	deletionPolicy: Retain
+//
+// or, to route the logs with FireLens to S3, Kinesis Data Firehose or OpenSearch:
+//
+//	driver: firelens
+//	destination: s3
+//	bucket: example-logs
+//	prefix: checkout
+//
+//	driver: firelens
+//	destination: firehose
+//	deliveryStream: example-logs
+//
+//	driver: firelens
+//	destination: opensearch
+//	endpoint: search-example-logs-abc123.us-west-2.es.amazonaws.com
+//	index: checkout
+//
+// Returns a message for each invalid property by its path in the properties, or nil if the properties are valid.
+func ParseLogging(properties map[string]interface{}) (*Logging, map[string]string) {
+	logging := DefaultLogging()
+	problems := make(map[string]string)
+
+	stringProperty := func(name string, pattern *regexp.Regexp, message string) string {
+		value, ok := properties[name]
+		if !ok {
+			return ""
+		}
+		s, isString := value.(string)
+		if !isString || (pattern != nil && !pattern.MatchString(s)) {
+			problems[name] = fmt.Sprintf(message, value)
+		}
+		return s
+	}
+
+	if driver, ok := properties["driver"]; ok {
+		if driver != AWSLogsDriver && driver != FireLensDriver && driver != NoLogsDriver {
+			problems["driver"] = fmt.Sprintf("Logging driver must be awslogs, firelens or none, got %v", driver)
+			return nil, problems
+		}
+		logging.Driver = driver.(string)
+	}
+
+	allowed := driverProperties[logging.Driver]
+	switch logging.Driver {
+	case AWSLogsDriver:
+		if value, ok := properties["retentionInDays"]; ok {
+			days, isNumber := value.(float64)
+			if !isNumber || !logRetentionDays[int(days)] || days != float64(int(days)) {
+				problems["retentionInDays"] = fmt.Sprintf(logRetentionMessage, value)
+			}
+			logging.RetentionInDays = int(days)
+		}
+		logging.KMSKeyARN = stringProperty("kmsKeyArn", kmsKeyARNPattern, kmsKeyARNMessage)
+		if value, ok := properties["deletionPolicy"]; ok {
+			if message := deletionPolicyProblem(value); message != "" {
+				problems["deletionPolicy"] = message
+			}
+			logging.DeletionPolicy = fmt.Sprintf("%v", value)
+		}
+	case FireLensDriver:
+		destination, _ := properties["destination"].(string)
+		if _, ok := destinationProperties[destination]; !ok {
+			problems["destination"] = fmt.Sprintf("FireLens destination must be s3, firehose or opensearch, got %v", properties["destination"])
+			return nil, problems
+		}
+		logging.Destination = destination
+		allowed = destinationProperties[destination]
+
+		switch destination {
+		case S3LogDestination:
+			logging.Bucket = stringProperty("bucket", bucketNamePattern, "Bucket must be an S3 bucket name, got %v")
+			logging.Prefix = stringProperty("prefix", nil, "Prefix must be a string, got %v")
+			if logging.Bucket == "" && problems["bucket"] == "" {
+				problems["bucket"] = "The s3 destination requires a bucket"
+			}
+		case FirehoseLogDestination:
+			logging.DeliveryStream = stringProperty("deliveryStream", deliveryStreamPattern, "Delivery stream must be a Kinesis Data Firehose delivery stream name, got %v")
+			if logging.DeliveryStream == "" && problems["deliveryStream"] == "" {
+				problems["deliveryStream"] = "The firehose destination requires a delivery stream"
+			}
+		case OpenSearchLogDestination:
+			logging.Endpoint = stringProperty("endpoint", openSearchEndpointPattern, "Endpoint must be the host name of an OpenSearch domain endpoint, like search-<domain>-<id>.<region>.es.amazonaws.com, got %v")
+			if match := openSearchEndpointPattern.FindStringSubmatch(logging.Endpoint); match != nil {
+				logging.Domain = match[1]
+			}
+			logging.Index = stringProperty("index", openSearchIndexPattern, "Index must be a lowercase OpenSearch index name, got %v")
+			if logging.Endpoint == "" && problems["endpoint"] == "" {
+				problems["endpoint"] = "The opensearch destination requires an endpoint"
+			}
+			if logging.Index == "" && problems["index"] == "" {
+				problems["index"] = "The opensearch destination requires an index"
+			}
+		}
+	}
+
+	for name := range properties {
+		if !allowed[name] {
+			problems[name] = fmt.Sprintf("Unknown property %s for logging driver %s", name, logging.Driver)
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, problems
+	}
+	return logging, nil
+}
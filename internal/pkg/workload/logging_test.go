@@ -0,0 +1,56 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package workload
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLoggingOpenSearchDomain(t *testing.T) {
+	tests := map[string]struct {
+		endpoint string
+
+		wantDomain   string
+		wantProblems map[string]string
+	}{
+		"public endpoint": {
+			endpoint:   "search-example-logs-abc123.us-west-2.es.amazonaws.com",
+			wantDomain: "example-logs",
+		},
+		"VPC endpoint": {
+			endpoint:   "vpc-logs-4yc2u5ju6l3ymfzbbfopcizq3m.eu-central-1.es.amazonaws.com",
+			wantDomain: "logs",
+		},
+		"URL instead of a host name": {
+			endpoint: "https://search-logs-abc123.us-west-2.es.amazonaws.com",
+			wantProblems: map[string]string{
+				"endpoint": "Endpoint must be the host name of an OpenSearch domain endpoint, like search-<domain>-<id>.<region>.es.amazonaws.com, got https://search-logs-abc123.us-west-2.es.amazonaws.com",
+			},
+		},
+		"endpoint without a domain name": {
+			endpoint: "es.us-west-2.amazonaws.com",
+			wantProblems: map[string]string{
+				"endpoint": "Endpoint must be the host name of an OpenSearch domain endpoint, like search-<domain>-<id>.<region>.es.amazonaws.com, got es.us-west-2.amazonaws.com",
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			logging, problems := ParseLogging(map[string]interface{}{
+				"driver":      FireLensDriver,
+				"destination": OpenSearchLogDestination,
+				"endpoint":    tc.endpoint,
+				"index":       "checkout",
+			})
+
+			require.Equal(t, tc.wantProblems, problems)
+			if tc.wantProblems == nil {
+				require.Equal(t, tc.endpoint, logging.Endpoint)
+				require.Equal(t, tc.wantDomain, logging.Domain)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package workload
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// MonitoringTrait is the name of the trait that creates CloudWatch alarms for a component instance
+const MonitoringTrait = "monitoring"
+
+var (
+	topicARNPattern     = regexp.MustCompile(`^arn:(aws[a-z-]*|\$\{AWS::Partition\}):sns:\S+:(\d{12}|\$\{AWS::AccountId\}):[A-Za-z0-9_-]{1,256}$`)
+	emailAddressPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+)
+
+// Monitoring holds the alarm thresholds and the notification targets of the monitoring trait
+type Monitoring struct {
+	// CPU and Memory are the average utilization of the ECS service, in percent, above which the alarms fire
+	CPU    float64
+	Memory float64
+	// RunningTasks is the number of running tasks below which the alarm fires
+	RunningTasks int
+	// UnhealthyHosts is the number of unhealthy load balancer targets above which the alarms fire
+	UnhealthyHosts int
+
+	// The alarms notify either an existing SNS topic, or a topic created with email subscriptions
+	TopicARN string
+	Emails   []string
+}
+
+// defaultMonitoring returns the thresholds the monitoring trait uses for the properties it is not given
+func defaultMonitoring() *Monitoring {
+	return &Monitoring{
+		CPU:            80,
+		Memory:         80,
+		RunningTasks:   1,
+		UnhealthyHosts: 0,
+	}
+}
+
+// ParseMonitoring reads the properties of the monitoring trait, which takes either an SNS topic ARN or a list of
+// email addresses to notify, and optionally overrides the default thresholds:
+//
+//	emails:
+//	  - oncall@example.com
+//	cpu: 90
+//	memory: 85
+//	runningTasks: 2
+//	unhealthyHosts: 0
+//
+// Returns a message for each invalid property by its path in the properties, or nil if the properties are valid.
+func ParseMonitoring(properties map[string]interface{}) (*Monitoring, map[string]string) {
+	monitoring := defaultMonitoring()
+	problems := make(map[string]string)
+
+	for name, value := range properties {
+		switch name {
+		case "cpu", "memory":
+			percent, isNumber := value.(float64)
+			if !isNumber || percent <= 0 || percent > 100 {
+				problems[name] = fmt.Sprintf("Utilization threshold must be a percentage between 0 and 100, got %v", value)
+			}
+			if name == "cpu" {
+				monitoring.CPU = percent
+			} else {
+				monitoring.Memory = percent
+			}
+		case "runningTasks", "unhealthyHosts":
+			count, isNumber := value.(float64)
+			if !isNumber || count < 0 || count != float64(int(count)) {
+				problems[name] = fmt.Sprintf("Task and host thresholds must be a whole number, got %v", value)
+			}
+			if name == "runningTasks" {
+				monitoring.RunningTasks = int(count)
+			} else {
+				monitoring.UnhealthyHosts = int(count)
+			}
+		case "topicArn":
+			s, isString := value.(string)
+			if !isString || !topicARNPattern.MatchString(s) {
+				problems[name] = fmt.Sprintf("Topic ARN must look like arn:aws:sns:<region>:<account>:<topic>, got %v", value)
+			}
+			monitoring.TopicARN = s
+		case "emails":
+			items, isList := value.([]interface{})
+			if !isList || len(items) == 0 {
+				problems[name] = "Emails must be a non-empty list of email addresses"
+				continue
+			}
+			for i, item := range items {
+				s, isString := item.(string)
+				if !isString || !emailAddressPattern.MatchString(s) {
+					problems[fmt.Sprintf("emails[%d]", i)] = fmt.Sprintf("Email must be an email address, got %v", item)
+				}
+				monitoring.Emails = append(monitoring.Emails, s)
+			}
+		default:
+			problems[name] = fmt.Sprintf("Unknown property %s, the monitoring trait takes topicArn, emails, cpu, memory, runningTasks and unhealthyHosts", name)
+		}
+	}
+
+	_, hasTopic := properties["topicArn"]
+	_, hasEmails := properties["emails"]
+	if hasTopic == hasEmails {
+		problems["topicArn"] = "The monitoring trait notifies either an SNS topic with topicArn or a list of emails"
+	}
+
+	if len(problems) > 0 {
+		return nil, problems
+	}
+	return monitoring, nil
+}
@@ -0,0 +1,131 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package workload
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oam-dev/oam-go-sdk/apis/core.oam.dev/v1alpha1"
+)
+
+// ErrReferenceCycle occurs when component instances refer to each other's outputs, so that none of them
+// can be deployed first.
+type ErrReferenceCycle struct {
+	Instances []string
+}
+
+func (e *ErrReferenceCycle) Error() string {
+	return fmt.Sprintf("Component instances %s refer to each other's outputs, so they cannot be deployed in any order", strings.Join(e.Instances, " -> "))
+}
+
+// componentOutputReferences lists the component instances whose outputs the parameter values of a component
+// instance refer to, in the order they are first referred to
+func componentOutputReferences(component *v1alpha1.ComponentConfiguration) []string {
+	references := []string{}
+	seen := make(map[string]bool)
+	for _, value := range component.ParameterValues {
+		expression, ok := ParseParameterExpression(value.Value)
+		if !ok || expression.Function != ComponentOutputFunction || len(expression.Args) == 0 {
+			continue
+		}
+		if !seen[expression.Args[0]] {
+			seen[expression.Args[0]] = true
+			references = append(references, expression.Args[0])
+		}
+	}
+	return references
+}
+
+// DeploymentOrder orders the component instances of an application so that every component instance comes after
+// the component instances whose outputs it refers to. Component instances that do not depend on each other keep the
+// order of the application configuration. References to instances that are not in the application are ignored.
+func DeploymentOrder(application *v1alpha1.ApplicationConfiguration) ([]*v1alpha1.ComponentConfiguration, error) {
+	components := make(map[string]*v1alpha1.ComponentConfiguration)
+	for i := range application.Spec.Components {
+		components[application.Spec.Components[i].InstanceName] = &application.Spec.Components[i]
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	order := []*v1alpha1.ComponentConfiguration{}
+	path := []string{}
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		component, ok := components[name]
+		if !ok || state[name] == visited {
+			return nil
+		}
+		if state[name] == visiting {
+			// The cycle is the part of the path that starts at this instance
+			for i, instance := range path {
+				if instance == name {
+					return &ErrReferenceCycle{Instances: append(append([]string{}, path[i:]...), name)}
+				}
+			}
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		for _, reference := range componentOutputReferences(component) {
+			if err := visit(reference); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		order = append(order, component)
+		return nil
+	}
+
+	for _, component := range application.Spec.Components {
+		if err := visit(component.InstanceName); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// ResolveComponentOutputs returns a copy of the component instance where the parameter values that refer to the
+// outputs of other component instances are replaced by the values of those outputs. The outputs are given by
+// instance name and output key. When outputs is nil, as for dry runs, the references are left as written.
+func ResolveComponentOutputs(component *v1alpha1.ComponentConfiguration, outputs map[string]map[string]string) (*v1alpha1.ComponentConfiguration, error) {
+	resolved := component.DeepCopy()
+	if outputs == nil {
+		return resolved, nil
+	}
+
+	for i, value := range resolved.ParameterValues {
+		expression, ok := ParseParameterExpression(value.Value)
+		if !ok || expression.Function != ComponentOutputFunction {
+			continue
+		}
+		if err := expression.Validate(); err != nil {
+			return nil, fmt.Errorf("Value of parameter %s is not a valid expression: %w", value.Name, err)
+		}
+
+		instance, key := expression.Args[0], expression.Args[1]
+		instanceOutputs, ok := outputs[instance]
+		if !ok {
+			return nil, fmt.Errorf("Parameter %s of component instance %s refers to component instance %s, which is not deployed", value.Name, component.InstanceName, instance)
+		}
+		output, ok := instanceOutputs[key]
+		if !ok {
+			return nil, fmt.Errorf("Parameter %s of component instance %s refers to output %s of component instance %s, which does not exist", value.Name, component.InstanceName, key, instance)
+		}
+		resolved.ParameterValues[i].Value = output
+	}
+	return resolved, nil
+}
+
+// ComponentOutputReference returns the parameter expression that refers to an output of a component instance, as
+// written in the OAM files and left in dry runs
+func ComponentOutputReference(instance, key string) string {
+	return fmt.Sprintf("[%s(%s, %s)]", ComponentOutputFunction, instance, key)
+}
@@ -0,0 +1,84 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package workload
+
+import (
+	"testing"
+
+	"github.com/oam-dev/oam-go-sdk/apis/core.oam.dev/v1alpha1"
+	"github.com/stretchr/testify/require"
+)
+
+func testApplication(references map[string]string, instances ...string) *v1alpha1.ApplicationConfiguration {
+	application := &v1alpha1.ApplicationConfiguration{}
+	for _, instance := range instances {
+		component := v1alpha1.ComponentConfiguration{InstanceName: instance}
+		if reference, ok := references[instance]; ok {
+			component.ParameterValues = []v1alpha1.ParameterValue{
+				{Name: "endpoint", Value: "[componentOutput(" + reference + ", WebPort80Endpoint)]"},
+			}
+		}
+		application.Spec.Components = append(application.Spec.Components, component)
+	}
+	return application
+}
+
+func instanceNames(components []*v1alpha1.ComponentConfiguration) []string {
+	names := []string{}
+	for _, component := range components {
+		names = append(names, component.InstanceName)
+	}
+	return names
+}
+
+func TestDeploymentOrder(t *testing.T) {
+	application := testApplication(map[string]string{"web": "api", "api": "db"}, "web", "worker", "api", "db")
+
+	order, err := DeploymentOrder(application)
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"db", "api", "web", "worker"}, instanceNames(order))
+}
+
+func TestDeploymentOrderCycle(t *testing.T) {
+	application := testApplication(map[string]string{"web": "api", "api": "web"}, "web", "api")
+
+	_, err := DeploymentOrder(application)
+
+	require.EqualError(t, err, "Component instances web -> api -> web refer to each other's outputs, so they cannot be deployed in any order")
+}
+
+func TestResolveComponentOutputs(t *testing.T) {
+	component := &testApplication(map[string]string{"web": "api"}, "web").Spec.Components[0]
+
+	resolved, err := ResolveComponentOutputs(component, map[string]map[string]string{
+		"api": {"WebPort80Endpoint": "api.elb.amazonaws.com:80"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "api.elb.amazonaws.com:80", resolved.ParameterValues[0].Value)
+	require.Equal(t, "[componentOutput(api, WebPort80Endpoint)]", component.ParameterValues[0].Value)
+
+	_, err = ResolveComponentOutputs(component, map[string]map[string]string{})
+	require.EqualError(t, err, "Parameter endpoint of component instance web refers to component instance api, which is not deployed")
+}
+
+func TestDiscoveryReferences(t *testing.T) {
+	application := testApplication(map[string]string{"api": "db"}, "api", "web", "worker", "db")
+	application.Spec.Components[0].ParameterValues = append(application.Spec.Components[0].ParameterValues, v1alpha1.ParameterValue{Name: "web-host", Value: "[discoveryHostname(web)]"})
+	application.Spec.Components[1].ParameterValues = []v1alpha1.ParameterValue{{Name: "api-host", Value: "[discoveryHostname(api)]"}}
+	application.Spec.Components[2].ParameterValues = []v1alpha1.ParameterValue{{Name: "api-host", Value: "[discoveryHostname(api)]"}}
+
+	// Discovery hostnames do not constrain the deployment order, even when component instances refer to each other's
+	order, err := DeploymentOrder(application)
+	require.NoError(t, err)
+	require.Equal(t, []string{"db", "api", "web", "worker"}, instanceNames(order))
+
+	// Each reference belongs to the component instance deployed after the other one
+	require.Empty(t, DiscoveryReferences(application, "db"))
+	require.Empty(t, DiscoveryReferences(application, "api"))
+	require.Equal(t, []DiscoveryReference{
+		{Client: "api", Server: "web"},
+		{Client: "web", Server: "api"},
+	}, DiscoveryReferences(application, "web"))
+	require.Equal(t, []DiscoveryReference{{Client: "worker", Server: "api"}}, DiscoveryReferences(application, "worker"))
+}
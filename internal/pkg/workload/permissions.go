@@ -0,0 +1,267 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package workload
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// PermissionsTrait is the name of the trait that grants AWS permissions to the task role of a component instance
+const PermissionsTrait = "permissions"
+
+var (
+	policyActionPattern       = regexp.MustCompile(`^(\*|[a-z0-9-]+:[A-Za-z0-9*?]+)$`)
+	policyResourcePattern     = regexp.MustCompile(`^(\*|arn:\S+)$`)
+	policySidPattern          = regexp.MustCompile(`^[A-Za-z0-9]*$`)
+	policyOperatorPattern     = regexp.MustCompile(`^((ForAllValues|ForAnyValue):)?[A-Za-z]+$`)
+	managedPolicyPattern      = regexp.MustCompile(`^arn:(aws[a-z-]*|\$\{AWS::Partition\}):iam::(aws|[0-9]{12}|\$\{AWS::AccountId\}):policy/[\w+=,.@/-]+$`)
+	policyConditionKeyPattern = regexp.MustCompile(`^[A-Za-z0-9-]+:\S+$`)
+)
+
+// Permissions holds the IAM policy statements and the managed policies of the permissions trait.
+// Actions, resources, condition keys and condition values are sorted and deduplicated, so that the task role
+// renders the same way for the same permissions and does not cause spurious changes to the stack.
+type Permissions struct {
+	Statements        []*PolicyStatement
+	ManagedPolicyARNs []string
+}
+
+// PolicyStatement is an IAM policy statement of the permissions trait
+type PolicyStatement struct {
+	Sid        string
+	Effect     string
+	Actions    []string
+	Resources  []string
+	Conditions []*PolicyCondition
+}
+
+// PolicyCondition is a condition key of an IAM policy statement, with the values it is compared to
+type PolicyCondition struct {
+	Operator string
+	Key      string
+	Values   []string
+}
+
+// ParsePermissions reads the properties of the permissions trait:
+//
+//	statements:
+//	  - effect: Allow
+//	    actions: ["s3:GetObject"]
+//	    resources: ["arn:${AWS::Partition}:s3:::my-bucket/*"]
+//	    condition:
+//	      StringEquals:
+//	        s3:ExistingObjectTag/team: orders
+//	managedPolicyArns:
+//	  - arn:aws:iam::aws:policy/AmazonSQSReadOnlyAccess
+//
+// Returns a message for each invalid property by its path in the properties, or nil if the properties are valid.
+func ParsePermissions(properties map[string]interface{}) (*Permissions, map[string]string) {
+	permissions := &Permissions{
+		Statements:        []*PolicyStatement{},
+		ManagedPolicyARNs: []string{},
+	}
+	problems := make(map[string]string)
+
+	for name := range properties {
+		if name != "statements" && name != "managedPolicyArns" {
+			problems[name] = fmt.Sprintf("Unknown property %s, the permissions trait takes statements and managedPolicyArns", name)
+		}
+	}
+
+	if value, ok := properties["statements"]; ok {
+		statements, isList := value.([]interface{})
+		if !isList {
+			problems["statements"] = "Statements must be a list of IAM policy statements"
+		}
+		for i, item := range statements {
+			statement := parsePolicyStatement(fmt.Sprintf("statements[%d]", i), item, problems)
+			if statement != nil {
+				permissions.Statements = append(permissions.Statements, statement)
+			}
+		}
+	}
+
+	if value, ok := properties["managedPolicyArns"]; ok {
+		arns, valid := stringList(value)
+		if !valid {
+			problems["managedPolicyArns"] = "Managed policy ARNs must be a list of strings"
+		}
+		for i, arn := range arns {
+			if !managedPolicyPattern.MatchString(arn) {
+				problems[fmt.Sprintf("managedPolicyArns[%d]", i)] = fmt.Sprintf("Managed policy ARN must look like arn:aws:iam::aws:policy/<name>, got %s", arn)
+			}
+		}
+		permissions.ManagedPolicyARNs = sortedUnique(arns)
+	}
+
+	if len(problems) > 0 {
+		return nil, problems
+	}
+	return permissions, nil
+}
+
+// parsePolicyStatement reads one IAM policy statement, and records its problems by property path
+func parsePolicyStatement(path string, value interface{}, problems map[string]string) *PolicyStatement {
+	properties, ok := value.(map[string]interface{})
+	if !ok {
+		problems[path] = "Statement must be a map with effect, actions, resources and condition"
+		return nil
+	}
+
+	statement := &PolicyStatement{
+		Effect:     "Allow",
+		Conditions: []*PolicyCondition{},
+	}
+	for name, property := range properties {
+		propertyPath := path + "." + name
+		switch name {
+		case "sid":
+			sid, isString := property.(string)
+			if !isString || !policySidPattern.MatchString(sid) {
+				problems[propertyPath] = fmt.Sprintf("Statement ID must only contain letters and digits, got %v", property)
+			}
+			statement.Sid = sid
+		case "effect":
+			if property != "Allow" && property != "Deny" {
+				problems[propertyPath] = fmt.Sprintf("Effect must be Allow or Deny, got %v", property)
+			}
+			statement.Effect, _ = property.(string)
+		case "actions":
+			actions, valid := stringList(property)
+			if !valid || len(actions) == 0 {
+				problems[propertyPath] = "Actions must be a non-empty list of strings"
+			}
+			for i, action := range actions {
+				if !policyActionPattern.MatchString(action) {
+					problems[fmt.Sprintf("%s[%d]", propertyPath, i)] = fmt.Sprintf("Action must look like <service>:<action>, got %s", action)
+				}
+			}
+			statement.Actions = sortedUnique(actions)
+		case "resources":
+			resources, valid := stringList(property)
+			if !valid || len(resources) == 0 {
+				problems[propertyPath] = "Resources must be a non-empty list of strings"
+			}
+			for i, resource := range resources {
+				if !policyResourcePattern.MatchString(resource) {
+					problems[fmt.Sprintf("%s[%d]", propertyPath, i)] = fmt.Sprintf("Resource must be * or an ARN, got %s", resource)
+				}
+			}
+			statement.Resources = sortedUnique(resources)
+		case "condition":
+			statement.Conditions = parsePolicyConditions(propertyPath, property, problems)
+		default:
+			problems[propertyPath] = fmt.Sprintf("Unknown property %s, statements take sid, effect, actions, resources and condition", name)
+		}
+	}
+
+	if statement.Actions == nil {
+		problems[path+".actions"] = "Statement has no actions"
+	}
+	if statement.Resources == nil {
+		problems[path+".resources"] = "Statement has no resources"
+	}
+	return statement
+}
+
+// parsePolicyConditions reads the condition block of an IAM policy statement, sorted by operator and key
+func parsePolicyConditions(path string, value interface{}, problems map[string]string) []*PolicyCondition {
+	conditions := []*PolicyCondition{}
+	operators, ok := value.(map[string]interface{})
+	if !ok {
+		problems[path] = "Condition must be a map of condition operators to condition keys and values"
+		return conditions
+	}
+
+	for operator, keys := range operators {
+		operatorPath := path + "." + operator
+		if !policyOperatorPattern.MatchString(operator) {
+			problems[operatorPath] = fmt.Sprintf("Unknown condition operator %s", operator)
+		}
+		keyValues, ok := keys.(map[string]interface{})
+		if !ok || len(keyValues) == 0 {
+			problems[operatorPath] = "Condition operator must map condition keys to values"
+			continue
+		}
+		for key, values := range keyValues {
+			keyPath := operatorPath + "." + key
+			if !policyConditionKeyPattern.MatchString(key) {
+				problems[keyPath] = fmt.Sprintf("Condition key must look like <service>:<key>, got %s", key)
+			}
+			list, valid := conditionValues(values)
+			if !valid {
+				problems[keyPath] = "Condition values must be a string, number, boolean or a non-empty list of those"
+			}
+			conditions = append(conditions, &PolicyCondition{
+				Operator: operator,
+				Key:      key,
+				Values:   sortedUnique(list),
+			})
+		}
+	}
+
+	sort.Slice(conditions, func(i, j int) bool {
+		if conditions[i].Operator != conditions[j].Operator {
+			return conditions[i].Operator < conditions[j].Operator
+		}
+		return conditions[i].Key < conditions[j].Key
+	})
+	return conditions
+}
+
+// conditionValues reads a condition value, or a list of them, as strings
+func conditionValues(value interface{}) ([]string, bool) {
+	items, isList := value.([]interface{})
+	if !isList {
+		items = []interface{}{value}
+	}
+	if len(items) == 0 {
+		return nil, false
+	}
+
+	values := []string{}
+	for _, item := range items {
+		switch item.(type) {
+		case string, float64, bool:
+			values = append(values, fmt.Sprintf("%v", item))
+		default:
+			return nil, false
+		}
+	}
+	return values, true
+}
+
+// stringList reads a list of strings
+func stringList(value interface{}) ([]string, bool) {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	values := []string{}
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil, false
+		}
+		values = append(values, s)
+	}
+	return values, true
+}
+
+// sortedUnique returns the sorted values without duplicates
+func sortedUnique(values []string) []string {
+	result := []string{}
+	seen := make(map[string]bool)
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
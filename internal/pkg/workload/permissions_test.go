@@ -0,0 +1,99 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package workload
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func permissionsProperties(t *testing.T, properties string) map[string]interface{} {
+	parsed := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal([]byte(properties), &parsed))
+	return parsed
+}
+
+func TestParsePermissions(t *testing.T) {
+	permissions, problems := ParsePermissions(permissionsProperties(t, `{
+		"statements": [{
+			"sid": "WriteReports",
+			"actions": ["s3:PutObject", "s3:AbortMultipartUpload", "s3:PutObject"],
+			"resources": ["arn:${AWS::Partition}:s3:::reports/${aws:username}/*"],
+			"condition": {
+				"StringEquals": {"aws:RequestedRegion": ["us-west-2", "us-east-1"]},
+				"Bool": {"aws:SecureTransport": true}
+			}
+		}],
+		"managedPolicyArns": ["arn:aws:iam::123456789012:policy/b", "arn:${AWS::Partition}:iam::aws:policy/a"]
+	}`))
+
+	require.Nil(t, problems)
+	require.Equal(t, &Permissions{
+		Statements: []*PolicyStatement{{
+			Sid:       "WriteReports",
+			Effect:    "Allow",
+			Actions:   []string{"s3:AbortMultipartUpload", "s3:PutObject"},
+			Resources: []string{"arn:${AWS::Partition}:s3:::reports/${aws:username}/*"},
+			Conditions: []*PolicyCondition{
+				{Operator: "Bool", Key: "aws:SecureTransport", Values: []string{"true"}},
+				{Operator: "StringEquals", Key: "aws:RequestedRegion", Values: []string{"us-east-1", "us-west-2"}},
+			},
+		}},
+		ManagedPolicyARNs: []string{"arn:${AWS::Partition}:iam::aws:policy/a", "arn:aws:iam::123456789012:policy/b"},
+	}, permissions)
+}
+
+func TestParsePermissionsProblems(t *testing.T) {
+	tests := map[string]struct {
+		properties string
+
+		want map[string]string
+	}{
+		"unknown property": {
+			properties: `{"roles": []}`,
+			want:       map[string]string{"roles": "Unknown property roles, the permissions trait takes statements and managedPolicyArns"},
+		},
+		"statements not a list": {
+			properties: `{"statements": "s3:*"}`,
+			want:       map[string]string{"statements": "Statements must be a list of IAM policy statements"},
+		},
+		"statement without actions and resources": {
+			properties: `{"statements": [{"effect": "Allow"}]}`,
+			want: map[string]string{
+				"statements[0].actions":   "Statement has no actions",
+				"statements[0].resources": "Statement has no resources",
+			},
+		},
+		"invalid effect, action and resource": {
+			properties: `{"statements": [{"effect": "Permit", "actions": ["PutObject"], "resources": ["my-bucket"]}]}`,
+			want: map[string]string{
+				"statements[0].effect":       "Effect must be Allow or Deny, got Permit",
+				"statements[0].actions[0]":   "Action must look like <service>:<action>, got PutObject",
+				"statements[0].resources[0]": "Resource must be * or an ARN, got my-bucket",
+			},
+		},
+		"invalid condition": {
+			properties: `{"statements": [{"actions": ["s3:GetObject"], "resources": ["*"], "condition": {"StringEquals": {"team": []}}}]}`,
+			want: map[string]string{
+				"statements[0].condition.StringEquals.team": "Condition values must be a string, number, boolean or a non-empty list of those",
+			},
+		},
+		"invalid managed policy ARN": {
+			properties: `{"managedPolicyArns": ["AmazonS3ReadOnlyAccess"]}`,
+			want: map[string]string{
+				"managedPolicyArns[0]": "Managed policy ARN must look like arn:aws:iam::aws:policy/<name>, got AmazonS3ReadOnlyAccess",
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			permissions, problems := ParsePermissions(permissionsProperties(t, tc.properties))
+
+			require.Nil(t, permissions)
+			require.Equal(t, tc.want, problems)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package workload
+
+import (
+	"fmt"
+
+	"github.com/oam-dev/oam-go-sdk/apis/core.oam.dev/v1alpha1"
+)
+
+// TargetGroupProbe finds the probe that translates to the load balancer target group health check of a container.
+// Only containers with ports in Server components are registered with the load balancer. Target group health checks
+// are HTTP or TCP requests: the readiness probe is preferred, since it determines whether the container should
+// receive traffic, falling back to the liveness probe.
+func TargetGroupProbe(workloadType string, container v1alpha1.Container) *v1alpha1.HealthProbe {
+	if workloadType != serverComponentWorkloadType || len(container.Ports) == 0 {
+		return nil
+	}
+
+	if isNetworkProbe(container.ReadinessProbe) {
+		return container.ReadinessProbe
+	}
+	if isNetworkProbe(container.LivenessProbe) {
+		return container.LivenessProbe
+	}
+	return nil
+}
+
+// ContainerHealthCheckProbe finds the probe that translates to the ECS container health check of a container.
+// ECS replaces the containers that fail their health check, like a failing liveness probe, so the liveness probe is
+// used. The readiness probe is only used when the container has no liveness probe, so that the containers declared
+// after it can wait for it to be ready. A probe that already translates to the target group health check is not
+// used for the container health check, so the liveness probe of a Server container whose readiness probe is the
+// target group health check is still used.
+func ContainerHealthCheckProbe(workloadType string, container v1alpha1.Container) *v1alpha1.HealthProbe {
+	probe := container.LivenessProbe
+	if probe == nil {
+		probe = container.ReadinessProbe
+	}
+	if probe == nil || probe == TargetGroupProbe(workloadType, container) {
+		return nil
+	}
+	if probe.Exec != nil || isNetworkProbe(probe) {
+		return probe
+	}
+	return nil
+}
+
+// Limits of the ECS container health check settings, in seconds except for the retries
+const (
+	healthCheckMinInterval    = 5
+	healthCheckMaxInterval    = 300
+	healthCheckMinRetries     = 1
+	healthCheckMaxRetries     = 10
+	healthCheckMaxStartPeriod = 300
+	healthCheckMinTimeout     = 2
+	healthCheckMaxTimeout     = 60
+)
+
+// ContainerHealthCheckProblems checks the settings of a probe that translates to an ECS container health check
+// against the limits of ECS, and returns a message for each invalid probe property by name. Unset properties
+// take the defaults of the health check, which are within the limits.
+func ContainerHealthCheckProblems(probe *v1alpha1.HealthProbe) map[string]string {
+	problems := make(map[string]string)
+	if probe.PeriodSeconds != 0 && (probe.PeriodSeconds < healthCheckMinInterval || probe.PeriodSeconds > healthCheckMaxInterval) {
+		problems["periodSeconds"] = fmt.Sprintf("ECS container health checks run every %d to %d seconds, got %d", healthCheckMinInterval, healthCheckMaxInterval, probe.PeriodSeconds)
+	}
+	if probe.FailureThreshold != 0 && (probe.FailureThreshold < healthCheckMinRetries || probe.FailureThreshold > healthCheckMaxRetries) {
+		problems["failureThreshold"] = fmt.Sprintf("ECS container health checks retry %d to %d times, got %d", healthCheckMinRetries, healthCheckMaxRetries, probe.FailureThreshold)
+	}
+	if probe.InitialDelaySeconds < 0 || probe.InitialDelaySeconds > healthCheckMaxStartPeriod {
+		problems["initialDelaySeconds"] = fmt.Sprintf("ECS container health checks start at most %d seconds after the container, got %d", healthCheckMaxStartPeriod, probe.InitialDelaySeconds)
+	}
+	if probe.TimeoutSeconds != 0 && (probe.TimeoutSeconds < healthCheckMinTimeout || probe.TimeoutSeconds > healthCheckMaxTimeout) {
+		problems["timeoutSeconds"] = fmt.Sprintf("ECS container health checks time out after %d to %d seconds, got %d", healthCheckMinTimeout, healthCheckMaxTimeout, probe.TimeoutSeconds)
+	}
+	return problems
+}
+
+// isNetworkProbe checks whether a probe sends a request to the container, rather than running a command in it
+func isNetworkProbe(probe *v1alpha1.HealthProbe) bool {
+	return probe != nil && (probe.HttpGet != nil || probe.TcpSocket != nil)
+}
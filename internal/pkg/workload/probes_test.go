@@ -0,0 +1,133 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package workload
+
+import (
+	"testing"
+
+	"github.com/oam-dev/oam-go-sdk/apis/core.oam.dev/v1alpha1"
+	"github.com/stretchr/testify/require"
+)
+
+func execProbe(command ...string) *v1alpha1.HealthProbe {
+	return &v1alpha1.HealthProbe{Exec: &v1alpha1.Exec{Command: command}}
+}
+
+func httpProbe(port int32, path string) *v1alpha1.HealthProbe {
+	return &v1alpha1.HealthProbe{HttpGet: &v1alpha1.HttpGet{Port: port, Path: path}}
+}
+
+func TestContainerHealthCheckProbeReadinessAndLiveness(t *testing.T) {
+	liveness := execProbe("/bin/live")
+	readiness := execProbe("/bin/ready")
+
+	// The readiness probe is not folded into the health check of a container with a liveness probe
+	container := v1alpha1.Container{Name: "app", LivenessProbe: liveness, ReadinessProbe: readiness}
+	require.Equal(t, liveness, ContainerHealthCheckProbe(workerComponentWorkloadType, container))
+
+	// Nor when the liveness probe is the target group health check
+	container = v1alpha1.Container{
+		Name:           "app",
+		Ports:          []v1alpha1.Port{{Name: "http", ContainerPort: 8080}},
+		LivenessProbe:  httpProbe(8080, "/live"),
+		ReadinessProbe: readiness,
+	}
+	require.Equal(t, container.LivenessProbe, TargetGroupProbe(serverComponentWorkloadType, container))
+	require.Nil(t, ContainerHealthCheckProbe(serverComponentWorkloadType, container))
+
+	// The readiness probe is the health check of a container without a liveness probe
+	container = v1alpha1.Container{Name: "cache", ReadinessProbe: readiness}
+	require.Equal(t, readiness, ContainerHealthCheckProbe(serverComponentWorkloadType, container))
+}
+
+func TestValidateProbesReportsIgnoredReadinessProbe(t *testing.T) {
+	schematic := &v1alpha1.ComponentSchematic{}
+	schematic.Spec.WorkloadType = workerComponentWorkloadType
+	container := v1alpha1.Container{Name: "app", LivenessProbe: execProbe("/bin/live"), ReadinessProbe: execProbe("/bin/ready")}
+
+	d := &diagnostics{}
+	validateProbes(d, schematic, container, "spec.containers[0]")
+	require.Len(t, d.list, 1)
+	require.Equal(t, "spec.containers[0].readinessProbe", d.list[0].Path)
+	require.Equal(t, SeverityWarning, d.list[0].Severity)
+	require.Contains(t, d.list[0].Message, "the readiness probe is ignored")
+}
+
+func TestProbeMappingByWorkloadType(t *testing.T) {
+	tcp := &v1alpha1.HealthProbe{TcpSocket: &v1alpha1.TcpSocket{Port: 5672}}
+	tests := map[string]struct {
+		workloadType string
+		container    v1alpha1.Container
+
+		wantTargetGroup *v1alpha1.HealthProbe
+		wantHealthCheck *v1alpha1.HealthProbe
+	}{
+		"server liveness httpGet is the target group health check only": {
+			workloadType: serverComponentWorkloadType,
+			container: v1alpha1.Container{
+				Ports:         []v1alpha1.Port{{ContainerPort: 8080}},
+				LivenessProbe: httpProbe(8080, "/healthz"),
+			},
+			wantTargetGroup: httpProbe(8080, "/healthz"),
+		},
+		"server readiness httpGet is preferred for the target group, liveness exec is the health check": {
+			workloadType: serverComponentWorkloadType,
+			container: v1alpha1.Container{
+				Ports:          []v1alpha1.Port{{ContainerPort: 8080}},
+				LivenessProbe:  execProbe("/bin/live"),
+				ReadinessProbe: httpProbe(8080, "/ready"),
+			},
+			wantTargetGroup: httpProbe(8080, "/ready"),
+			wantHealthCheck: execProbe("/bin/live"),
+		},
+		"server network probe without ports is the health check": {
+			workloadType:    serverComponentWorkloadType,
+			container:       v1alpha1.Container{ReadinessProbe: httpProbe(9100, "/ready")},
+			wantHealthCheck: httpProbe(9100, "/ready"),
+		},
+		"server readiness httpGet is the target group health check, liveness httpGet is the health check": {
+			workloadType: serverComponentWorkloadType,
+			container: v1alpha1.Container{
+				Ports:          []v1alpha1.Port{{ContainerPort: 8080}},
+				LivenessProbe:  httpProbe(8080, "/live"),
+				ReadinessProbe: httpProbe(8080, "/ready"),
+			},
+			wantTargetGroup: httpProbe(8080, "/ready"),
+			wantHealthCheck: httpProbe(8080, "/live"),
+		},
+		"worker httpGet is the health check": {
+			workloadType:    workerComponentWorkloadType,
+			container:       v1alpha1.Container{LivenessProbe: httpProbe(8080, "/healthz")},
+			wantHealthCheck: httpProbe(8080, "/healthz"),
+		},
+		"worker tcpSocket is the health check": {
+			workloadType:    workerComponentWorkloadType,
+			container:       v1alpha1.Container{Ports: []v1alpha1.Port{{ContainerPort: 5672}}, LivenessProbe: tcp},
+			wantHealthCheck: tcp,
+		},
+		"probe without handler is ignored": {
+			workloadType: workerComponentWorkloadType,
+			container:    v1alpha1.Container{LivenessProbe: &v1alpha1.HealthProbe{}},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.wantTargetGroup, TargetGroupProbe(tc.workloadType, tc.container))
+			require.Equal(t, tc.wantHealthCheck, ContainerHealthCheckProbe(tc.workloadType, tc.container))
+		})
+	}
+}
+
+func TestContainerHealthCheckProblems(t *testing.T) {
+	require.Empty(t, ContainerHealthCheckProblems(execProbe("/bin/live")))
+	require.Empty(t, ContainerHealthCheckProblems(&v1alpha1.HealthProbe{PeriodSeconds: 300, FailureThreshold: 10, InitialDelaySeconds: 300, TimeoutSeconds: 60}))
+
+	problems := ContainerHealthCheckProblems(&v1alpha1.HealthProbe{PeriodSeconds: 4, FailureThreshold: 11, InitialDelaySeconds: 301, TimeoutSeconds: 1})
+	require.Equal(t, map[string]string{
+		"periodSeconds":       "ECS container health checks run every 5 to 300 seconds, got 4",
+		"failureThreshold":    "ECS container health checks retry 1 to 10 times, got 11",
+		"initialDelaySeconds": "ECS container health checks start at most 300 seconds after the container, got 301",
+		"timeoutSeconds":      "ECS container health checks time out after 2 to 60 seconds, got 1",
+	}, problems)
+}
@@ -0,0 +1,296 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package workload
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+
+	"github.com/oam-dev/oam-go-sdk/apis/core.oam.dev/v1alpha1"
+)
+
+// traitPropertyValidators check the properties of the supported traits, and return a message for each invalid property by name
+var traitPropertyValidators = map[string]func(properties map[string]interface{}) map[string]string{
+	"manual-scaler": func(properties map[string]interface{}) map[string]string {
+		if value, ok := properties["replicaCount"]; ok {
+			if count, isNumber := value.(float64); !isNumber || count < 0 || count != float64(int32(count)) {
+				return map[string]string{"replicaCount": fmt.Sprintf("Replica count must be a non-negative integer, got %v", value)}
+			}
+		}
+		return nil
+	},
+	"efs": func(properties map[string]interface{}) map[string]string {
+		if value, ok := properties["fileSystem"]; ok && value != "component" && value != "environment" && value != "host" {
+			return map[string]string{"fileSystem": fmt.Sprintf("File system must be component, environment or host, got %v", value)}
+		}
+		return nil
+	},
+	ExposureTrait: func(properties map[string]interface{}) map[string]string {
+		if value, ok := properties["type"]; ok && value != PublicExposure && value != InternalExposure {
+			return map[string]string{"type": fmt.Sprintf("Exposure type must be public or internal, got %v", value)}
+		}
+		return nil
+	},
+	PermissionsTrait: func(properties map[string]interface{}) map[string]string {
+		_, problems := ParsePermissions(properties)
+		return problems
+	},
+	IngressPolicyTrait: func(properties map[string]interface{}) map[string]string {
+		_, problems := ParseIngressPolicy(properties)
+		return problems
+	},
+	SidecarTrait: func(properties map[string]interface{}) map[string]string {
+		_, problems := ParseSidecars(properties)
+		return problems
+	},
+	LoggingTrait: func(properties map[string]interface{}) map[string]string {
+		_, problems := ParseLogging(properties)
+		return problems
+	},
+	MonitoringTrait: func(properties map[string]interface{}) map[string]string {
+		_, problems := ParseMonitoring(properties)
+		return problems
+	},
+	TracingTrait: func(properties map[string]interface{}) map[string]string {
+		_, problems := ParseTracing(properties)
+		return problems
+	},
+	ExecTrait: ParseExec,
+}
+
+// ValidateReferences checks that the application configuration and the component schematics fit together:
+// that every component instance has a schematic, that parameter values match the declared parameters, that
+// component instances do not refer to each other's outputs in a cycle, and that the properties of the supported
+// traits are valid. Lists the problems as errors, and traits bound to components
+// they do not apply to as warnings.
+func (workload *OamWorkload) ValidateReferences() []*Diagnostic {
+	result := []*Diagnostic{}
+
+	names := make([]string, 0, len(workload.ComponentSchematics))
+	for name := range workload.ComponentSchematics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		d := &diagnostics{
+			file: workload.ComponentSchematicFiles[name],
+			kind: componentSchematicKind,
+			name: name,
+		}
+		validateSchematicReferences(d, workload.ComponentSchematics[name])
+		result = append(result, d.list...)
+	}
+
+	if workload.ApplicationConfiguration != nil {
+		d := &diagnostics{
+			file: workload.ApplicationConfigurationFile,
+			kind: applicationConfigurationKind,
+			name: workload.ApplicationConfiguration.Name,
+		}
+		validateApplicationReferences(d, workload.ApplicationConfiguration, workload.ComponentSchematics)
+		result = append(result, d.list...)
+	}
+
+	return result
+}
+
+// validateSchematicReferences checks that containers only refer to declared parameters
+func validateSchematicReferences(d *diagnostics, schematic *v1alpha1.ComponentSchematic) {
+	declared := make(map[string]bool)
+	for i, parameter := range schematic.Spec.Parameters {
+		path := fmt.Sprintf("spec.parameters[%d]", i)
+		if declared[parameter.Name] {
+			d.fail(path, "Parameter %s is declared more than once", parameter.Name)
+		}
+		declared[parameter.Name] = true
+		if parameter.Default != "" {
+			if err := checkParameterType(parameter.ParameterType, parameter.Default); err != nil {
+				d.fail(path+".default", "Default value of parameter %s %v", parameter.Name, err)
+			}
+		}
+	}
+
+	containers := make(map[string]bool)
+	for i, container := range schematic.Spec.Containers {
+		containerPath := fmt.Sprintf("spec.containers[%d]", i)
+		if containers[container.Name] {
+			d.fail(containerPath+".name", "Container name %s is used more than once", container.Name)
+		}
+		containers[container.Name] = true
+
+		for j, env := range container.Env {
+			if env.FromParam != "" && !declared[env.FromParam] {
+				d.fail(fmt.Sprintf("%s.env[%d].fromParam", containerPath, j), "Environment variable %s refers to parameter %s, which is not declared", env.Name, env.FromParam)
+			}
+		}
+		configDirectories := make(map[string]bool)
+		for j, config := range container.Config {
+			if problem := ConfigFilePathProblem(config.Path); problem != "" {
+				d.fail(fmt.Sprintf("%s.config[%d].path", containerPath, j), "%s", problem)
+			} else if directory := path.Dir(config.Path); !configDirectories[directory] {
+				configDirectories[directory] = true
+				d.warn(fmt.Sprintf("%s.config[%d].path", containerPath, j), "Config file %s is written to a volume mounted over %s, which hides the files of image %s in that directory, the directory must be empty in the image", config.Path, directory, container.Image)
+			}
+			if config.FromParam != "" && !declared[config.FromParam] {
+				d.fail(fmt.Sprintf("%s.config[%d].fromParam", containerPath, j), "Config file %s refers to parameter %s, which is not declared", config.Path, config.FromParam)
+			}
+		}
+	}
+}
+
+// validateApplicationReferences checks the component instances against their schematics, and the properties of their traits
+func validateApplicationReferences(d *diagnostics, application *v1alpha1.ApplicationConfiguration, schematics map[string]*v1alpha1.ComponentSchematic) {
+	declared := make(map[string]bool)
+	workloadTypes := make(map[string]string)
+	for _, component := range application.Spec.Components {
+		declared[component.InstanceName] = true
+		if schematic, ok := schematics[component.ComponentName]; ok {
+			workloadTypes[component.InstanceName] = schematic.Spec.WorkloadType
+		}
+	}
+
+	instances := make(map[string]bool)
+	for i, component := range application.Spec.Components {
+		componentPath := fmt.Sprintf("spec.components[%d]", i)
+		if instances[component.InstanceName] {
+			d.fail(componentPath+".instanceName", "Instance name %s is used more than once", component.InstanceName)
+		}
+		instances[component.InstanceName] = true
+
+		for j, trait := range component.Traits {
+			validateTraitProperties(d, fmt.Sprintf("%s.traits[%d]", componentPath, j), trait)
+		}
+
+		schematic, ok := schematics[component.ComponentName]
+		if !ok {
+			d.fail(componentPath+".componentName", "Component instance %s refers to component %s, but no file provided the component schematic", component.InstanceName, component.ComponentName)
+			continue
+		}
+		for j, trait := range component.Traits {
+			if (trait.Name == IngressPolicyTrait || trait.Name == ExposureTrait) && schematic.Spec.WorkloadType != serverComponentWorkloadType {
+				d.warn(fmt.Sprintf("%s.traits[%d]", componentPath, j), "Trait %s only applies to Server components, it is ignored for component %s", trait.Name, component.ComponentName)
+			}
+			if trait.Name == SidecarTrait {
+				validateSidecarNames(d, fmt.Sprintf("%s.traits[%d]", componentPath, j), trait, schematic)
+			}
+		}
+
+		parameters := make(map[string]v1alpha1.Parameter)
+		for _, parameter := range schematic.Spec.Parameters {
+			parameters[parameter.Name] = parameter
+		}
+		values := make(map[string]bool)
+		for j, value := range component.ParameterValues {
+			path := fmt.Sprintf("%s.parameterValues[%d]", componentPath, j)
+			parameter, ok := parameters[value.Name]
+			if !ok {
+				d.fail(path, "Component %s does not declare parameter %s", component.ComponentName, value.Name)
+				continue
+			}
+			values[value.Name] = true
+			if expression, ok := ParseParameterExpression(value.Value); ok {
+				if err := expression.Validate(); err != nil {
+					d.fail(path+".value", "Value of parameter %s is not a valid expression: %v", value.Name, err)
+				} else if expression.Function != ImportValueFunction && !declared[expression.Args[0]] {
+					d.fail(path+".value", "Value of parameter %s refers to component instance %s, which is not in the application", value.Name, expression.Args[0])
+				} else if expression.Function == DiscoveryHostnameFunction && workloadTypes[expression.Args[0]] != "" && workloadTypes[expression.Args[0]] != serverComponentWorkloadType {
+					d.warn(path+".value", "Value of parameter %s refers to the discovery hostname of component instance %s, which is not a Server component and accepts no traffic from other components", value.Name, expression.Args[0])
+				}
+				continue
+			}
+			if isParameterExpressionLike(value.Value) {
+				d.warn(path+".value", "Value of parameter %s calls an unsupported function, it is used as a literal string", value.Name)
+			}
+			if err := checkParameterType(parameter.ParameterType, value.Value); err != nil {
+				d.fail(path+".value", "Value of parameter %s %v", value.Name, err)
+			}
+		}
+		for _, parameter := range schematic.Spec.Parameters {
+			if parameter.Required && parameter.Default == "" && !values[parameter.Name] {
+				d.fail(componentPath+".parameterValues", "Component %s requires a value for parameter %s", component.ComponentName, parameter.Name)
+			}
+		}
+	}
+
+	if _, err := DeploymentOrder(application); err != nil {
+		d.fail("spec.components", "%v", err)
+	}
+}
+
+// validateTraitProperties checks that the properties of a supported trait can be parsed and are valid
+func validateTraitProperties(d *diagnostics, path string, trait v1alpha1.TraitBinding) {
+	validator, ok := traitPropertyValidators[trait.Name]
+	if !ok {
+		return
+	}
+
+	properties, err := traitBindingProperties(trait)
+	if err != nil {
+		d.fail(path+".properties", "Could not parse the properties of trait %s: %v", trait.Name, err)
+		return
+	}
+
+	messages := validator(properties)
+	names := make([]string, 0, len(messages))
+	for name := range messages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		d.fail(path+".properties."+name, "%s", messages[name])
+	}
+}
+
+// traitBindingProperties parses the properties of a trait bound to a component instance
+func traitBindingProperties(trait v1alpha1.TraitBinding) (map[string]interface{}, error) {
+	properties := make(map[string]interface{})
+	if len(trait.Properties.Raw) > 0 {
+		if err := json.Unmarshal(trait.Properties.Raw, &properties); err != nil {
+			return nil, err
+		}
+	}
+	return properties, nil
+}
+
+// validateSidecarNames checks that the sidecars of a component instance do not reuse the names of its containers,
+// the sidecar properties themselves are checked with the other trait properties
+func validateSidecarNames(d *diagnostics, path string, trait v1alpha1.TraitBinding, schematic *v1alpha1.ComponentSchematic) {
+	properties, err := traitBindingProperties(trait)
+	if err != nil {
+		return
+	}
+	sidecars, problems := ParseSidecars(properties)
+	if problems != nil {
+		return
+	}
+
+	containers := make(map[string]bool)
+	for _, container := range schematic.Spec.Containers {
+		containers[container.Name] = true
+	}
+	for k, sidecar := range sidecars {
+		if containers[sidecar.Name] {
+			d.fail(fmt.Sprintf("%s.properties.containers[%d].name", path, k), "Sidecar %s has the same name as a container of component %s", sidecar.Name, schematic.Name)
+		}
+	}
+}
+
+// checkParameterType checks that a parameter value can be parsed as the declared type
+func checkParameterType(parameterType v1alpha1.ParameterType, value string) error {
+	switch parameterType {
+	case v1alpha1.Number:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("must be a number, got %s", value)
+		}
+	case v1alpha1.Boolean:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("must be a boolean, got %s", value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package workload
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// SidecarTrait is the name of the trait that adds containers like agents and proxies to the task of a component instance
+const SidecarTrait = "sidecar"
+
+var sidecarNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,255}$`)
+
+// reservedContainerNames are the names of the containers that oam-ecs generates in the task
+var reservedContainerNames = map[string]bool{
+	"config-init":      true,
+	"healthcheck-init": true,
+	logRouterName:      true,
+}
+
+// Sidecar is a container added to the task of a component instance by the sidecar trait
+type Sidecar struct {
+	Name  string
+	Image string
+	// CPU is in vCPU and MemoryMiB in MiB, both are added to the task size
+	CPU       float64
+	MemoryMiB int64
+	// Essential sidecars stop the task when they exit
+	Essential bool
+	// Condition is the state the sidecar must reach before the component's containers start
+	Condition   string
+	Environment []SidecarEnv
+	Ports       []SidecarPort
+	// FireLens is set for the log router that the firelens logging driver adds to the task
+	FireLens bool
+}
+
+// SidecarEnv is an environment variable of a sidecar. Values of the form secretsmanager:<secret> or
+// ssm:<parameter> are injected from Secrets Manager or SSM Parameter Store, like the containers' variables.
+type SidecarEnv struct {
+	Name  string
+	Value string
+}
+
+// SidecarPort is a port a sidecar listens on for the component's containers
+type SidecarPort struct {
+	Port     int32
+	Protocol string
+}
+
+// sidecarCatalog lists the reusable sidecar specs that the sidecar trait can refer to with from. Images are pinned
+// to a version, so that redeploying a component instance does not change its sidecars.
+var sidecarCatalog = map[string]Sidecar{
+	"xray-daemon": {
+		Image:     "public.ecr.aws/xray/aws-xray-daemon:3.3.7",
+		CPU:       0.03125,
+		MemoryMiB: 256,
+		Condition: "START",
+		Ports:     []SidecarPort{{Port: 2000, Protocol: "udp"}},
+	},
+	"datadog-agent": {
+		Image:       "public.ecr.aws/datadog/agent:7",
+		CPU:         0.125,
+		MemoryMiB:   256,
+		Condition:   "START",
+		Environment: []SidecarEnv{{Name: "ECS_FARGATE", Value: "true"}},
+		Ports:       []SidecarPort{{Port: 8125, Protocol: "udp"}, {Port: 8126, Protocol: "tcp"}},
+	},
+}
+
+// sidecarCatalogNames lists the names of the catalog's sidecar specs, for messages
+func sidecarCatalogNames() string {
+	names := make([]string, 0, len(sidecarCatalog))
+	for name := range sidecarCatalog {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// ParseSidecars reads the properties of the sidecar trait. Each container either refers to a spec of the catalog
+// with from, optionally overriding its settings, or gives at least a name and an image:
+//
+//	containers:
+//	  - from: xray-daemon
+//	  - from: datadog-agent
+//	    env:
+//	      DD_API_KEY: secretsmanager:datadog-api-key
+//	  - name: metrics
+//	    image: example/metrics-agent:1.2
+//	    cpu: 0.125
+//	    memory: 128M
+//	    essential: false
+//
+// Returns a message for each invalid property by its path in the properties, or nil if the properties are valid.
+func ParseSidecars(properties map[string]interface{}) ([]*Sidecar, map[string]string) {
+	problems := make(map[string]string)
+
+	for name := range properties {
+		if name != "containers" {
+			problems[name] = fmt.Sprintf("Unknown property %s, the sidecar trait takes containers", name)
+		}
+	}
+
+	items, ok := properties["containers"].([]interface{})
+	if !ok || len(items) == 0 {
+		problems["containers"] = "Containers must be a non-empty list of sidecar containers"
+	}
+
+	sidecars := []*Sidecar{}
+	names := make(map[string]bool)
+	for i, item := range items {
+		path := fmt.Sprintf("containers[%d]", i)
+		sidecar := parseSidecar(path, item, problems)
+		if sidecar == nil {
+			continue
+		}
+		if names[sidecar.Name] {
+			problems[path+".name"] = fmt.Sprintf("Sidecar name %s is used more than once", sidecar.Name)
+		}
+		names[sidecar.Name] = true
+		sidecars = append(sidecars, sidecar)
+	}
+
+	if len(problems) > 0 {
+		return nil, problems
+	}
+	return sidecars, nil
+}
+
+// parseSidecar reads one sidecar container, and records its problems by property path
+func parseSidecar(path string, value interface{}, problems map[string]string) *Sidecar {
+	properties, ok := value.(map[string]interface{})
+	if !ok {
+		problems[path] = "Sidecar must be a map with from, name, image, cpu, memory, essential and env"
+		return nil
+	}
+
+	sidecar := &Sidecar{Condition: "START"}
+	if from, ok := properties["from"]; ok {
+		spec, found := sidecarCatalog[fmt.Sprintf("%v", from)]
+		if !found {
+			problems[path+".from"] = fmt.Sprintf("Unknown sidecar %v, the catalog has %s", from, sidecarCatalogNames())
+			return nil
+		}
+		*sidecar = spec
+		sidecar.Name = fmt.Sprintf("%v", from)
+		sidecar.Environment = append([]SidecarEnv{}, spec.Environment...)
+	}
+
+	for name, property := range properties {
+		propertyPath := path + "." + name
+		switch name {
+		case "from":
+		case "name":
+			s, isString := property.(string)
+			if !isString || !sidecarNamePattern.MatchString(s) {
+				problems[propertyPath] = fmt.Sprintf("Sidecar name must only contain letters, digits, hyphens and underscores, got %v", property)
+			} else if reservedContainerNames[s] {
+				problems[propertyPath] = fmt.Sprintf("Sidecar name %s is reserved for a container generated by oam-ecs", s)
+			}
+			sidecar.Name = s
+		case "image":
+			s, isString := property.(string)
+			if !isString || s == "" {
+				problems[propertyPath] = fmt.Sprintf("Sidecar image must be a non-empty string, got %v", property)
+			}
+			sidecar.Image = s
+		case "cpu":
+			cpu, isNumber := property.(float64)
+			if !isNumber || cpu <= 0 {
+				problems[propertyPath] = fmt.Sprintf("Sidecar CPU must be a positive number of vCPU, got %v", property)
+			}
+			sidecar.CPU = cpu
+		case "memory":
+			quantity, err := resource.ParseQuantity(fmt.Sprintf("%v", property))
+			if err != nil || quantity.Sign() <= 0 {
+				problems[propertyPath] = fmt.Sprintf("Sidecar memory must be a positive quantity like 256M, got %v", property)
+				continue
+			}
+			sidecar.MemoryMiB = quantity.MilliValue() / int64(1000) / int64(1000000)
+		case "essential":
+			essential, isBool := property.(bool)
+			if !isBool {
+				problems[propertyPath] = fmt.Sprintf("Sidecar essential must be true or false, got %v", property)
+			}
+			sidecar.Essential = essential
+		case "env":
+			variables, isMap := property.(map[string]interface{})
+			if !isMap {
+				problems[propertyPath] = "Sidecar env must map variable names to values"
+				continue
+			}
+			sidecar.Environment = mergeSidecarEnv(propertyPath, sidecar.Environment, variables, problems)
+		default:
+			problems[propertyPath] = fmt.Sprintf("Unknown property %s, sidecars take from, name, image, cpu, memory, essential and env", name)
+		}
+	}
+
+	if sidecar.Name == "" {
+		problems[path+".name"] = "Sidecar without from must have a name"
+	}
+	if sidecar.Image == "" {
+		problems[path+".image"] = "Sidecar without from must have an image"
+	}
+	return sidecar
+}
+
+// mergeSidecarEnv overrides the environment of a sidecar with the given variables, sorted by name
+func mergeSidecarEnv(path string, environment []SidecarEnv, variables map[string]interface{}, problems map[string]string) []SidecarEnv {
+	values := make(map[string]string)
+	for _, env := range environment {
+		values[env.Name] = env.Value
+	}
+	for name, value := range variables {
+		switch value.(type) {
+		case string, float64, bool:
+			values[name] = fmt.Sprintf("%v", value)
+		default:
+			problems[path+"."+name] = fmt.Sprintf("Value of sidecar environment variable %s must be a string, number or boolean", name)
+		}
+	}
+
+	merged := []SidecarEnv{}
+	for name, value := range values {
+		merged = append(merged, SidecarEnv{Name: name, Value: value})
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Name < merged[j].Name
+	})
+	return merged
+}
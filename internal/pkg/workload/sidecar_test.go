@@ -0,0 +1,139 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package workload
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func sidecarProperties(t *testing.T, properties string) map[string]interface{} {
+	parsed := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal([]byte(properties), &parsed))
+	return parsed
+}
+
+func TestParseSidecarsFromCatalog(t *testing.T) {
+	sidecars, problems := ParseSidecars(sidecarProperties(t, `{
+		"containers": [
+			{"from": "xray-daemon"},
+			{"from": "datadog-agent", "name": "datadog", "memory": "512M", "env": {"DD_API_KEY": "secretsmanager:datadog-api-key", "ECS_FARGATE": false}}
+		]
+	}`))
+
+	require.Nil(t, problems)
+	require.Equal(t, []*Sidecar{
+		{
+			Name:        "xray-daemon",
+			Image:       "public.ecr.aws/xray/aws-xray-daemon:3.3.7",
+			CPU:         0.03125,
+			MemoryMiB:   256,
+			Condition:   "START",
+			Environment: []SidecarEnv{},
+			Ports:       []SidecarPort{{Port: 2000, Protocol: "udp"}},
+		},
+		{
+			Name:      "datadog",
+			Image:     "public.ecr.aws/datadog/agent:7",
+			CPU:       0.125,
+			MemoryMiB: 512,
+			Condition: "START",
+			Environment: []SidecarEnv{
+				{Name: "DD_API_KEY", Value: "secretsmanager:datadog-api-key"},
+				{Name: "ECS_FARGATE", Value: "false"},
+			},
+			Ports: []SidecarPort{{Port: 8125, Protocol: "udp"}, {Port: 8126, Protocol: "tcp"}},
+		},
+	}, sidecars)
+
+	// Overriding the environment of a sidecar leaves the catalog untouched
+	require.Equal(t, []SidecarEnv{{Name: "ECS_FARGATE", Value: "true"}}, sidecarCatalog["datadog-agent"].Environment)
+}
+
+func TestParseSidecarsSizes(t *testing.T) {
+	tests := map[string]struct {
+		cpu    string
+		memory string
+
+		wantCPU       float64
+		wantMemoryMiB int64
+	}{
+		"decimal megabytes": {
+			cpu:           "0.25",
+			memory:        `"128M"`,
+			wantCPU:       0.25,
+			wantMemoryMiB: 128,
+		},
+		"gigabytes": {
+			cpu:           "1",
+			memory:        `"1G"`,
+			wantCPU:       1,
+			wantMemoryMiB: 1000,
+		},
+		"binary mebibytes are converted to decimal megabytes": {
+			cpu:           "0.125",
+			memory:        `"256Mi"`,
+			wantCPU:       0.125,
+			wantMemoryMiB: 268,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sidecars, problems := ParseSidecars(sidecarProperties(t, `{"containers": [{"name": "agent", "image": "example/agent:1.0", "cpu": `+tc.cpu+`, "memory": `+tc.memory+`}]}`))
+
+			require.Nil(t, problems)
+			require.Equal(t, tc.wantCPU, sidecars[0].CPU)
+			require.Equal(t, tc.wantMemoryMiB, sidecars[0].MemoryMiB)
+		})
+	}
+}
+
+func TestParseSidecarsProblems(t *testing.T) {
+	tests := map[string]struct {
+		properties string
+
+		want map[string]string
+	}{
+		"no containers": {
+			properties: `{"containers": []}`,
+			want:       map[string]string{"containers": "Containers must be a non-empty list of sidecar containers"},
+		},
+		"unknown catalog sidecar": {
+			properties: `{"containers": [{"from": "envoy"}]}`,
+			want:       map[string]string{"containers[0].from": "Unknown sidecar envoy, the catalog has datadog-agent, xray-daemon"},
+		},
+		"sidecar without from, name and image": {
+			properties: `{"containers": [{"cpu": 0.25}]}`,
+			want: map[string]string{
+				"containers[0].name":  "Sidecar without from must have a name",
+				"containers[0].image": "Sidecar without from must have an image",
+			},
+		},
+		"invalid sizes": {
+			properties: `{"containers": [{"from": "xray-daemon", "cpu": 0, "memory": "lots"}]}`,
+			want: map[string]string{
+				"containers[0].cpu":    "Sidecar CPU must be a positive number of vCPU, got 0",
+				"containers[0].memory": "Sidecar memory must be a positive quantity like 256M, got lots",
+			},
+		},
+		"reserved and duplicate names": {
+			properties: `{"containers": [{"from": "xray-daemon", "name": "log_router"}, {"from": "xray-daemon"}, {"from": "xray-daemon"}]}`,
+			want: map[string]string{
+				"containers[0].name": "Sidecar name log_router is reserved for a container generated by oam-ecs",
+				"containers[2].name": "Sidecar name xray-daemon is used more than once",
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sidecars, problems := ParseSidecars(sidecarProperties(t, tc.properties))
+
+			require.Nil(t, sidecars)
+			require.Equal(t, tc.want, problems)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package workload
+
+import (
+	"fmt"
+)
+
+// TracingTrait is the name of the trait that sends the traces of a component instance to AWS X-Ray
+const TracingTrait = "tracing"
+
+const (
+	// xrayDaemonName is the name of the X-Ray daemon spec in the sidecar catalog, and of the container it adds
+	xrayDaemonName = "xray-daemon"
+
+	// XRayDaemonAddressEnv is the environment variable the X-Ray SDKs read the address of the daemon from
+	XRayDaemonAddressEnv = "AWS_XRAY_DAEMON_ADDRESS"
+)
+
+// Tracing holds the settings of the tracing trait
+type Tracing struct {
+	// DaemonName is the name of the X-Ray daemon container in the task
+	DaemonName string
+	// DaemonAddress is the address the component's containers send their trace segments to. Containers of a task
+	// share its network namespace, so the daemon listens on the loopback address.
+	DaemonAddress string
+}
+
+// XRayDaemon is the X-Ray daemon container that the tracing trait adds to the task, unless the sidecar trait
+// already adds a container named xray-daemon
+func XRayDaemon() *Sidecar {
+	daemon := sidecarCatalog[xrayDaemonName]
+	daemon.Name = xrayDaemonName
+	return &daemon
+}
+
+// ParseTracing reads the properties of the tracing trait, which takes none:
+//
+//	traits:
+//	  - name: tracing
+//
+// The X-Ray daemon can be customized by adding it with the sidecar trait, from xray-daemon.
+// Returns a message for each invalid property by its path in the properties, or nil if the properties are valid.
+func ParseTracing(properties map[string]interface{}) (*Tracing, map[string]string) {
+	problems := make(map[string]string)
+
+	for name := range properties {
+		problems[name] = fmt.Sprintf("Unknown property %s, the tracing trait takes no properties", name)
+	}
+
+	if len(problems) > 0 {
+		return nil, problems
+	}
+	daemon := XRayDaemon()
+	return &Tracing{
+		DaemonName:    daemon.Name,
+		DaemonAddress: fmt.Sprintf("127.0.0.1:%d", daemon.Ports[0].Port),
+	}, nil
+}
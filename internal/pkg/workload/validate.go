@@ -0,0 +1,214 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package workload
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/oam-dev/oam-go-sdk/apis/core.oam.dev/v1alpha1"
+)
+
+// Severity describes whether a diagnostic prevents the workload from being deployed
+type Severity string
+
+const (
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+const (
+	componentSchematicKind       = "ComponentSchematic"
+	applicationConfigurationKind = "ApplicationConfiguration"
+)
+
+// supportedTraits lists the traits that translate to ECS, any other trait is dropped
+var supportedTraits = map[string]bool{
+	"manual-scaler":    true,
+	"efs":              true,
+	PermissionsTrait:   true,
+	IngressPolicyTrait: true,
+	ExposureTrait:      true,
+	SidecarTrait:       true,
+	LoggingTrait:       true,
+	MonitoringTrait:    true,
+	TracingTrait:       true,
+	ExecTrait:          true,
+}
+
+// Diagnostic describes an attribute of the OAM workload that cannot be translated to ECS as written
+type Diagnostic struct {
+	Severity Severity `json:"severity"`
+	File     string   `json:"file"`
+	Kind     string   `json:"kind"`
+	Name     string   `json:"name"`
+	// Path is the JSON path of the attribute in the OAM object
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (diagnostic *Diagnostic) String() string {
+	if diagnostic.File == "" {
+		return fmt.Sprintf("%s %s, %s: %s", diagnostic.Kind, diagnostic.Name, diagnostic.Path, diagnostic.Message)
+	}
+	return fmt.Sprintf("%s: %s %s, %s: %s", diagnostic.File, diagnostic.Kind, diagnostic.Name, diagnostic.Path, diagnostic.Message)
+}
+
+// diagnostics collects the diagnostics for one OAM object
+type diagnostics struct {
+	file string
+	kind string
+	name string
+	list []*Diagnostic
+}
+
+func (d *diagnostics) warn(path string, format string, args ...interface{}) {
+	d.add(SeverityWarning, path, format, args...)
+}
+
+func (d *diagnostics) fail(path string, format string, args ...interface{}) {
+	d.add(SeverityError, path, format, args...)
+}
+
+func (d *diagnostics) add(severity Severity, path string, format string, args ...interface{}) {
+	d.list = append(d.list, &Diagnostic{
+		Severity: severity,
+		File:     d.file,
+		Kind:     d.kind,
+		Name:     d.name,
+		Path:     path,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// Validate checks the workload for attributes that the ECS translation drops or cannot translate as written,
+// and lists them as warnings
+func (workload *OamWorkload) Validate() []*Diagnostic {
+	result := []*Diagnostic{}
+
+	names := make([]string, 0, len(workload.ComponentSchematics))
+	for name := range workload.ComponentSchematics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		d := &diagnostics{
+			file: workload.ComponentSchematicFiles[name],
+			kind: componentSchematicKind,
+			name: name,
+		}
+		validateComponentSchematic(d, workload.ComponentSchematics[name])
+		result = append(result, d.list...)
+	}
+
+	if workload.ApplicationConfiguration != nil {
+		d := &diagnostics{
+			file: workload.ApplicationConfigurationFile,
+			kind: applicationConfigurationKind,
+			name: workload.ApplicationConfiguration.Name,
+		}
+		validateApplicationConfiguration(d, workload.ApplicationConfiguration)
+		result = append(result, d.list...)
+	}
+
+	return result
+}
+
+// validateComponentSchematic checks a component schematic for attributes that are dropped
+func validateComponentSchematic(d *diagnostics, schematic *v1alpha1.ComponentSchematic) {
+	if schematic.Spec.OsType != "" && schematic.Spec.OsType != "linux" {
+		d.warn("spec.osType", "Only linux containers are supported, the OS type %s is ignored", schematic.Spec.OsType)
+	}
+	if schematic.Spec.Arch != "" && schematic.Spec.Arch != "amd64" {
+		d.warn("spec.arch", "Only amd64 containers are supported, the architecture %s is ignored", schematic.Spec.Arch)
+	}
+	if len(schematic.Spec.WorkloadSettings.Raw) > 0 && string(schematic.Spec.WorkloadSettings.Raw) != "null" {
+		d.warn("spec.workloadSettings", "Workload settings are not supported and are ignored")
+	}
+
+	for i, container := range schematic.Spec.Containers {
+		containerPath := fmt.Sprintf("spec.containers[%d]", i)
+		for j, extended := range container.Resources.Extended {
+			d.warn(fmt.Sprintf("%s.resources.extended[%d]", containerPath, j), "Extended resources are not supported, the resource %s is ignored", extended.Name)
+		}
+		validateProbes(d, schematic, container, containerPath)
+	}
+}
+
+// validateApplicationConfiguration checks an application configuration for attributes that are dropped
+func validateApplicationConfiguration(d *diagnostics, application *v1alpha1.ApplicationConfiguration) {
+	for i, variable := range application.Spec.Variables {
+		d.warn(fmt.Sprintf("spec.variables[%d]", i), "Variables are not supported, the variable %s is ignored", variable.Name)
+	}
+	for i, scope := range application.Spec.Scopes {
+		d.warn(fmt.Sprintf("spec.scopes[%d]", i), "Application scopes are not supported, the scope %s is ignored", scope.Name)
+	}
+
+	for i, component := range application.Spec.Components {
+		componentPath := fmt.Sprintf("spec.components[%d]", i)
+		for j, scope := range component.ApplicationScopes {
+			d.warn(fmt.Sprintf("%s.applicationScopes[%d]", componentPath, j), "Application scopes are not supported, the scope %s is ignored", scope)
+		}
+		for j, trait := range component.Traits {
+			if !supportedTraits[trait.Name] {
+				d.warn(fmt.Sprintf("%s.traits[%d]", componentPath, j), "Trait %s is not supported and is ignored", trait.Name)
+			}
+		}
+	}
+}
+
+// validateProbes checks how a container's liveness and readiness probes translate to
+// the load balancer target group health check and the ECS container health check
+func validateProbes(d *diagnostics, schematic *v1alpha1.ComponentSchematic, container v1alpha1.Container, containerPath string) {
+	targetGroupProbe := TargetGroupProbe(schematic.Spec.WorkloadType, container)
+	healthCheckProbe := ContainerHealthCheckProbe(schematic.Spec.WorkloadType, container)
+
+	probes := []struct {
+		name  string
+		probe *v1alpha1.HealthProbe
+	}{
+		{"livenessProbe", container.LivenessProbe},
+		{"readinessProbe", container.ReadinessProbe},
+	}
+	for _, p := range probes {
+		if p.probe == nil {
+			continue
+		}
+		path := fmt.Sprintf("%s.%s", containerPath, p.name)
+
+		switch p.probe {
+		case targetGroupProbe:
+			if p.probe.HttpGet != nil && len(p.probe.HttpGet.HttpHeaders) > 0 {
+				d.warn(path+".httpGet.httpHeaders", "Load balancer health checks cannot send custom headers, the headers are ignored")
+			}
+		case healthCheckProbe:
+			if p.probe.SuccessThreshold > 1 {
+				d.warn(path+".successThreshold", "ECS container health checks do not support a success threshold, the threshold is ignored")
+			}
+			problems := ContainerHealthCheckProblems(p.probe)
+			names := make([]string, 0, len(problems))
+			for name := range problems {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				d.fail(path+"."+name, "%s", problems[name])
+			}
+		default:
+			if p.probe.Exec == nil && !isNetworkProbe(p.probe) {
+				d.warn(path, "Probe has no exec, httpGet or tcpSocket handler, so it is ignored")
+			} else if p.probe == container.ReadinessProbe && container.LivenessProbe != nil {
+				d.warn(path, "ECS has a single container health check, translated from the liveness probe of container %s, so the readiness probe is ignored and the containers declared after it only wait for it to start", container.Name)
+			} else {
+				d.warn(path, "Probe cannot be translated to a health check of container %s, so it is ignored", container.Name)
+			}
+		}
+	}
+
+	if schematic.Spec.WorkloadType == serverComponentWorkloadType && len(container.Ports) > 0 &&
+		targetGroupProbe == nil && healthCheckProbe != nil {
+		d.warn(containerPath, "Load balancer health checks cannot run exec probes, so the load balancer checks whether container %s accepts TCP connections on its traffic port", container.Name)
+	}
+}
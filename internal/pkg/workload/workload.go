@@ -28,11 +28,17 @@ type OamWorkloadProps struct {
 type OamWorkload struct {
 	ApplicationConfiguration *v1alpha1.ApplicationConfiguration
 	ComponentSchematics      map[string]*v1alpha1.ComponentSchematic
+
+	// Files from which the application configuration and the component schematics (by name) were read
+	ApplicationConfigurationFile string
+	ComponentSchematicFiles      map[string]string
 }
 
 func NewOamWorkload(input *OamWorkloadProps) (*OamWorkload, error) {
 	var applicationConfiguration *v1alpha1.ApplicationConfiguration
+	var applicationConfigurationFile string
 	componentSchematics := make(map[string]*v1alpha1.ComponentSchematic)
+	componentSchematicFiles := make(map[string]string)
 
 	v1alpha1.SchemeBuilder.AddToScheme(scheme.Scheme)
 	decode := scheme.Codecs.UniversalDeserializer().Decode
@@ -72,6 +78,7 @@ func NewOamWorkload(input *OamWorkloadProps) (*OamWorkload, error) {
 					return nil, fmt.Errorf("Multiple application configuration files found, only one is allowed per application")
 				}
 				applicationConfiguration = obj.(*v1alpha1.ApplicationConfiguration)
+				applicationConfigurationFile = fileLocation
 			case *v1alpha1.ComponentSchematic:
 				schematic := obj.(*v1alpha1.ComponentSchematic)
 
@@ -85,6 +92,7 @@ func NewOamWorkload(input *OamWorkloadProps) (*OamWorkload, error) {
 				}
 
 				componentSchematics[schematic.Name] = schematic
+				componentSchematicFiles[schematic.Name] = fileLocation
 			default:
 				log.Errorf("Found invalid object in file %s\n", fileLocation)
 				return nil, fmt.Errorf("Object type %s is not supported", kind)
@@ -99,7 +107,14 @@ func NewOamWorkload(input *OamWorkloadProps) (*OamWorkload, error) {
 	}
 
 	return &OamWorkload{
-		ApplicationConfiguration: applicationConfiguration,
-		ComponentSchematics:      componentSchematics,
+		ApplicationConfiguration:     applicationConfiguration,
+		ComponentSchematics:          componentSchematics,
+		ApplicationConfigurationFile: applicationConfigurationFile,
+		ComponentSchematicFiles:      componentSchematicFiles,
 	}, nil
 }
+
+// HasLoadBalancer returns whether the instances of a component schematic are deployed behind a load balancer
+func HasLoadBalancer(schematic *v1alpha1.ComponentSchematic) bool {
+	return schematic.Spec.WorkloadType == serverComponentWorkloadType
+}